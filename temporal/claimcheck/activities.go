@@ -0,0 +1,67 @@
+package claimcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// DeleteWorkflowBlobsActivityName is the name the DeleteWorkflowBlobs activity is registered under.
+	DeleteWorkflowBlobsActivityName = "claim-check-delete-workflow-blobs"
+	// CollectGarbageActivityName is the name the CollectGarbage activity is registered under.
+	CollectGarbageActivityName = "claim-check-collect-garbage"
+)
+
+// Activities deletes the blobs of workflows that are no longer needed.
+type Activities struct {
+	Store  Store         // nil if claim checks are disabled, the activities do nothing then
+	Client client.Client // reads the histories of the workflows
+}
+
+// DeleteWorkflowBlobs deletes the blobs referred to by the histories of the given closed workflow runs, and returns
+// the number of blobs deleted. The blobs of open runs are kept, and runs without a history left are skipped. The
+// caller makes sure that no other history it still needs refers to the blobs.
+func (a *Activities) DeleteWorkflowBlobs(ctx context.Context, executions []workflow.Execution) (int, error) {
+	if a.Store == nil {
+		return 0, nil
+	}
+	logger := activity.GetLogger(ctx)
+	deleted := 0
+	for _, execution := range executions {
+		keys, err := HistoryKeys(ctx, a.Client, execution.ID, execution.RunID)
+		var notFound *serviceerror.NotFound
+		switch {
+		case errors.Is(err, ErrWorkflowOpen):
+			logger.Warn("Keeping the claim check blobs of an open workflow", "workflowId", execution.ID, "runId", execution.RunID)
+			continue
+		case errors.As(err, &notFound):
+			continue
+		case err != nil:
+			return deleted, fmt.Errorf("unable to read the history of %s: %w", execution.ID, err)
+		}
+		for _, key := range keys {
+			if err := a.Store.Delete(ctx, key); err != nil {
+				return deleted, fmt.Errorf("unable to delete blob %s: %w", key, err)
+			}
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		logger.Info("Deleted claim check blobs", "deleted", deleted, "workflows", len(executions))
+	}
+	return deleted, nil
+}
+
+// CollectGarbage used to delete the blobs that were not put since before, which also deleted blobs still referred
+// to by closed histories and schedules. It deletes nothing now, and is only registered for the orchestrator runs
+// started before DeleteWorkflowBlobs replaced it.
+func (a *Activities) CollectGarbage(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
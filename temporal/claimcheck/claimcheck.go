@@ -0,0 +1,157 @@
+// Package claimcheck keeps large payloads out of workflow histories: payloads above a size threshold are
+// written to a blob store and replaced by a reference to the blob, the claim check.
+//
+// Blobs are keyed by the SHA-256 of their content, so equal payloads share a blob. A blob is never deleted by age,
+// as closed histories, schedules and other runs may still refer to it: HistoryKeys finds the blobs a workflow
+// history refers to, and the owner of the workflow deletes them once the history is no longer needed.
+package claimcheck
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/proxy"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+const (
+	// MetadataEncodingClaimCheck is the encoding of the references. Their data is the key of the blob.
+	MetadataEncodingClaimCheck = "binary/claim-check"
+	// MetadataClaimCheckSize holds the size of the stored payload in bytes, for information only.
+	MetadataClaimCheckSize = "claim-check-size"
+)
+
+// ErrWorkflowOpen is returned by HistoryKeys for workflow runs that are not closed.
+var ErrWorkflowOpen = errors.New("workflow is still open")
+
+// DefaultThreshold is the payload size in bytes above which payloads are stored, well below the size at which
+// the Temporal server starts warning about large payloads.
+const DefaultThreshold = 64 * 1024
+
+// Codec is a converter.PayloadCodec that moves payloads larger than Threshold to Store.
+type Codec struct {
+	Store     Store
+	Threshold int
+}
+
+var _ converter.PayloadCodec = (*Codec)(nil)
+
+// NewCodec returns a codec storing the payloads above DefaultThreshold in store.
+func NewCodec(store Store) *Codec {
+	return &Codec{Store: store, Threshold: DefaultThreshold}
+}
+
+// Encode replaces the large payloads by references to their blobs.
+func (c *Codec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	result := make([]*commonpb.Payload, len(payloads))
+	for i, payload := range payloads {
+		if payload.Size() <= c.Threshold {
+			result[i] = payload
+			continue
+		}
+		data, err := payload.Marshal()
+		if err != nil {
+			return payloads, err
+		}
+		sum := sha256.Sum256(data)
+		key := hex.EncodeToString(sum[:])
+		if err := c.Store.Put(context.Background(), key, data); err != nil {
+			return payloads, err
+		}
+		result[i] = &commonpb.Payload{
+			Metadata: map[string][]byte{
+				converter.MetadataEncoding: []byte(MetadataEncodingClaimCheck),
+				MetadataClaimCheckSize:     []byte(fmt.Sprint(len(data))),
+			},
+			Data: []byte(key),
+		}
+	}
+	return result, nil
+}
+
+// Decode replaces the references by the payloads they refer to, the other payloads are returned as they are.
+func (c *Codec) Decode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	result := make([]*commonpb.Payload, len(payloads))
+	for i, payload := range payloads {
+		key, isReference := referenceKey(payload)
+		if !isReference {
+			result[i] = payload
+			continue
+		}
+		data, err := c.Store.Get(context.Background(), key)
+		if err != nil {
+			return payloads, fmt.Errorf("unable to resolve claim check: %w", err)
+		}
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != key {
+			return payloads, fmt.Errorf("blob %s does not match its key", key)
+		}
+		result[i] = &commonpb.Payload{}
+		if err := result[i].Unmarshal(data); err != nil {
+			return payloads, err
+		}
+	}
+	return result, nil
+}
+
+// referenceKey returns the key of the blob a reference refers to, false if the payload is not a reference.
+func referenceKey(payload *commonpb.Payload) (string, bool) {
+	if string(payload.GetMetadata()[converter.MetadataEncoding]) != MetadataEncodingClaimCheck {
+		return "", false
+	}
+	return string(payload.GetData()), true
+}
+
+// HistoryKeys returns the keys of the blobs referred to by the history of a closed workflow run, sorted. It fails
+// with ErrWorkflowOpen if the run is still open or continued as new, as its next run may refer to the same blobs.
+func HistoryKeys(ctx context.Context, c client.Client, workflowID string, runID string) ([]string, error) {
+	seen := make(map[string]bool)
+	visit := proxy.VisitPayloadsOptions{
+		SkipSearchAttributes: true,
+		Visitor: func(_ *proxy.VisitPayloadsContext, payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+			for _, payload := range payloads {
+				if key, isReference := referenceKey(payload); isReference {
+					seen[key] = true
+				}
+			}
+			return payloads, nil
+		},
+	}
+	var last *historypb.HistoryEvent
+	iter := c.GetWorkflowHistory(ctx, workflowID, runID, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		if err := proxy.VisitPayloads(ctx, event, visit); err != nil {
+			return nil, err
+		}
+		last = event
+	}
+	if !closedEventTypes[last.GetEventType()] {
+		return nil, fmt.Errorf("%w: %s", ErrWorkflowOpen, workflowID)
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// closedEventTypes are the events closing a workflow run for good.
+var closedEventTypes = map[enumspb.EventType]bool{
+	enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:  true,
+	enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:     true,
+	enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:   true,
+	enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED: true,
+	enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:  true,
+}
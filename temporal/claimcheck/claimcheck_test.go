@@ -0,0 +1,130 @@
+package claimcheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func Test_Codec_RoundTrip(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	dc := converter.NewCodecDataConverter(converter.GetDefaultDataConverter(), &Codec{Store: store, Threshold: 100})
+
+	// Small payloads stay in the history.
+	small, err := dc.ToPayload("small")
+	require.NoError(t, err)
+	require.Equal(t, converter.MetadataEncodingJSON, string(small.Metadata[converter.MetadataEncoding]))
+
+	large := map[string]string{"extraFieldA": strings.Repeat("a", 1000)}
+	payload, err := dc.ToPayload(large)
+	require.NoError(t, err)
+	require.Equal(t, MetadataEncodingClaimCheck, string(payload.Metadata[converter.MetadataEncoding]))
+	require.Less(t, payload.Size(), 200)
+	var value map[string]string
+	require.NoError(t, dc.FromPayload(payload, &value))
+	require.Equal(t, large, value)
+
+	// The same content is stored once.
+	again, err := dc.ToPayload(large)
+	require.NoError(t, err)
+	require.Equal(t, payload.Data, again.Data)
+	blobs, err := store.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+
+	// Tampered blobs are rejected.
+	require.NoError(t, os.WriteFile(filepath.Join(store.Dir, string(payload.Data)), []byte("tampered"), 0o600))
+	require.ErrorContains(t, dc.FromPayload(payload, &value), "does not match its key")
+
+	require.NoError(t, store.Delete(context.Background(), string(payload.Data)))
+	require.ErrorIs(t, dc.FromPayload(payload, &value), ErrBlobNotFound)
+}
+
+// history returns an iterator over events, each with a payload as the input of its activity or workflow.
+func history(t *testing.T, payloads map[enumspb.EventType]*commonpb.Payload, eventTypes ...enumspb.EventType) *mocks.HistoryEventIterator {
+	iter := mocks.NewHistoryEventIterator(t)
+	for _, eventType := range eventTypes {
+		event := &historypb.HistoryEvent{EventType: eventType}
+		input := &commonpb.Payloads{Payloads: []*commonpb.Payload{payloads[eventType]}}
+		switch eventType {
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+			event.Attributes = &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+				WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{Input: input},
+			}
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			event.Attributes = &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+				ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{Input: input},
+			}
+		}
+		iter.On("HasNext").Return(true).Once()
+		iter.On("Next").Return(event, nil).Once()
+	}
+	iter.On("HasNext").Return(false).Once()
+	return iter
+}
+
+func Test_Activities_DeleteWorkflowBlobs(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	codec := &Codec{Store: store, Threshold: 10}
+	encode := func(value string) *commonpb.Payload {
+		payload, err := converter.NewCodecDataConverter(converter.GetDefaultDataConverter(), codec).ToPayload(value)
+		require.NoError(t, err)
+		return payload
+	}
+	input, result, running := encode(strings.Repeat("i", 100)), encode(strings.Repeat("r", 100)), encode(strings.Repeat("o", 100))
+	small, err := converter.GetDefaultDataConverter().ToPayload("small")
+	require.NoError(t, err)
+
+	c := mocks.NewClient(t)
+	c.On("GetWorkflowHistory", mock.Anything, "closed", "run-1", false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT).Return(history(t,
+		map[enumspb.EventType]*commonpb.Payload{
+			enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED: input,
+			enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:    small,
+		},
+		enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED, enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED, enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED,
+	))
+	c.On("GetWorkflowHistory", mock.Anything, "open", "run-2", false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT).Return(history(t,
+		map[enumspb.EventType]*commonpb.Payload{enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED: running},
+		enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED,
+	))
+	gone := mocks.NewHistoryEventIterator(t)
+	gone.On("HasNext").Return(true).Once()
+	gone.On("Next").Return(nil, serviceerror.NewNotFound("workflow not found")).Once()
+	c.On("GetWorkflowHistory", mock.Anything, "gone", "", false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT).Return(gone)
+
+	env := (&testsuite.WorkflowTestSuite{}).NewTestActivityEnvironment()
+	activities := &Activities{Store: store, Client: c}
+	env.RegisterActivity(activities.DeleteWorkflowBlobs)
+	val, err := env.ExecuteActivity(activities.DeleteWorkflowBlobs, []workflow.Execution{{ID: "closed", RunID: "run-1"}, {ID: "open", RunID: "run-2"}, {ID: "gone"}})
+	require.NoError(t, err)
+	var deleted int
+	require.NoError(t, val.Get(&deleted))
+	require.Equal(t, 1, deleted)
+
+	// Only the blob of the closed workflow is deleted, the result blob no history refers to and the blob of the
+	// open workflow are kept.
+	_, err = store.Get(ctx, string(input.Data))
+	require.ErrorIs(t, err, ErrBlobNotFound)
+	for _, payload := range []*commonpb.Payload{result, running} {
+		_, err = store.Get(ctx, string(payload.Data))
+		require.NoError(t, err)
+	}
+
+	require.ErrorContains(t, store.Put(ctx, "../escape", nil), "invalid blob key")
+}
@@ -0,0 +1,174 @@
+// Package codec encrypts the payloads of the samples with AES-GCM before they are sent to the Temporal server,
+// so item data is not stored in workflow histories as plaintext.
+//
+// The keys are read from a YAML key file. The active key encrypts new payloads, every key in the file decrypts,
+// so keys are rotated by adding a new key, making it the active one, and removing the old key once no open
+// workflow history references it any more.
+package codec
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MetadataEncodingEncrypted is the encoding of encrypted payloads. Their data is the nonce followed by
+	// the sealed original payload.
+	MetadataEncodingEncrypted = "binary/encrypted"
+	// MetadataEncryptionKeyID holds the ID of the key a payload was encrypted with.
+	MetadataEncryptionKeyID = "encryption-key-id"
+)
+
+// KeySize is the size of the AES-256 keys in bytes.
+const KeySize = 32
+
+var unknownKeyError = errors.New("unknown encryption key")
+
+// Keys holds the encryption keys by ID and the ID of the active key.
+type Keys struct {
+	ActiveKeyID string
+	keys        map[string]cipher.AEAD
+}
+
+// keyFile is the format of the key file.
+type keyFile struct {
+	ActiveKey string            `yaml:"activeKey"`
+	Keys      map[string]string `yaml:"keys"` // base64 encoded keys by ID
+}
+
+// LoadKeyFile reads the keys from a YAML key file, e.g.
+//
+//	activeKey: 2026-10
+//	keys:
+//	  2026-10: <base64 encoded 32 bytes>
+//	  2026-01: <base64 encoded 32 bytes>
+func LoadKeyFile(path string) (*Keys, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open key file: %w", err)
+	}
+	defer f.Close()
+	var file keyFile
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("unable to read key file %s: %w", path, err)
+	}
+	encoded := make(map[string][]byte, len(file.Keys))
+	for id, key := range file.Keys {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %s in %s is not base64 encoded: %w", id, path, err)
+		}
+		encoded[id] = decoded
+	}
+	keys, err := NewKeys(file.ActiveKey, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// NewKeys returns the keys for AES-256 keys by ID. activeKeyID must be one of them.
+func NewKeys(activeKeyID string, keys map[string][]byte) (*Keys, error) {
+	if _, exists := keys[activeKeyID]; !exists {
+		return nil, fmt.Errorf("the active key %q is not defined", activeKeyID)
+	}
+	k := &Keys{ActiveKeyID: activeKeyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("key %s has %d bytes, expected %d", id, len(key), KeySize)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.keys[id] = aead
+	}
+	return k, nil
+}
+
+// GenerateKey returns a new random key, base64 encoded for the key file.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Codec is a converter.PayloadCodec that encrypts payloads with the active key and decrypts them with the key
+// they were encrypted with. Payloads that are not encrypted are decoded as they are, so histories written
+// before encryption was enabled stay readable.
+type Codec struct {
+	Keys *Keys
+}
+
+var _ converter.PayloadCodec = (*Codec)(nil)
+
+// Encode encrypts the payloads with the active key.
+func (c *Codec) Encode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	aead := c.Keys.keys[c.Keys.ActiveKeyID]
+	result := make([]*commonpb.Payload, len(payloads))
+	for i, payload := range payloads {
+		plaintext, err := payload.Marshal()
+		if err != nil {
+			return payloads, err
+		}
+		nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+		if _, err := rand.Read(nonce); err != nil {
+			return payloads, err
+		}
+		result[i] = &commonpb.Payload{
+			Metadata: map[string][]byte{
+				converter.MetadataEncoding: []byte(MetadataEncodingEncrypted),
+				MetadataEncryptionKeyID:    []byte(c.Keys.ActiveKeyID),
+			},
+			// The key ID is authenticated, so it cannot be changed without failing the decryption.
+			Data: aead.Seal(nonce, nonce, plaintext, []byte(c.Keys.ActiveKeyID)),
+		}
+	}
+	return result, nil
+}
+
+// Decode decrypts the encrypted payloads, the others are returned as they are.
+func (c *Codec) Decode(payloads []*commonpb.Payload) ([]*commonpb.Payload, error) {
+	result := make([]*commonpb.Payload, len(payloads))
+	for i, payload := range payloads {
+		if string(payload.GetMetadata()[converter.MetadataEncoding]) != MetadataEncodingEncrypted {
+			result[i] = payload
+			continue
+		}
+		keyID := string(payload.GetMetadata()[MetadataEncryptionKeyID])
+		aead, exists := c.Keys.keys[keyID]
+		if !exists {
+			return payloads, fmt.Errorf("%w %q", unknownKeyError, keyID)
+		}
+		data := payload.GetData()
+		if len(data) < aead.NonceSize() {
+			return payloads, errors.New("encrypted payload is too short")
+		}
+		plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(keyID))
+		if err != nil {
+			return payloads, fmt.Errorf("unable to decrypt payload with key %s: %w", keyID, err)
+		}
+		result[i] = &commonpb.Payload{}
+		if err := result[i].Unmarshal(plaintext); err != nil {
+			return payloads, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package codec
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/converter"
+)
+
+func testKeys(t *testing.T, activeKeyID string, ids ...string) *Keys {
+	keys := make(map[string][]byte, len(ids))
+	for i, id := range ids {
+		keys[id] = bytes.Repeat([]byte{byte(i + 1)}, KeySize)
+	}
+	k, err := NewKeys(activeKeyID, keys)
+	require.NoError(t, err)
+	return k
+}
+
+// newDataConverter returns the default data converter with its payloads encrypted by keys.
+func newDataConverter(keys *Keys) converter.DataConverter {
+	return converter.NewCodecDataConverter(converter.GetDefaultDataConverter(), &Codec{Keys: keys})
+}
+
+func Test_Codec_RoundTrip(t *testing.T) {
+	dc := newDataConverter(testKeys(t, "old", "old"))
+	payload, err := dc.ToPayload(map[string]string{"extraFieldA": "secret"})
+	require.NoError(t, err)
+	require.Equal(t, MetadataEncodingEncrypted, string(payload.Metadata[converter.MetadataEncoding]))
+	require.Equal(t, "old", string(payload.Metadata[MetadataEncryptionKeyID]))
+	require.NotContains(t, string(payload.Data), "secret")
+
+	// After the rotation, new payloads use the new key and the old payloads are still decrypted.
+	rotated := newDataConverter(testKeys(t, "new", "old", "new"))
+	var value map[string]string
+	require.NoError(t, rotated.FromPayload(payload, &value))
+	require.Equal(t, "secret", value["extraFieldA"])
+	newPayload, err := rotated.ToPayload("value")
+	require.NoError(t, err)
+	require.Equal(t, "new", string(newPayload.Metadata[MetadataEncryptionKeyID]))
+
+	// Payloads of a removed key cannot be decrypted.
+	err = newDataConverter(testKeys(t, "new", "new")).FromPayload(payload, &value)
+	require.ErrorIs(t, err, unknownKeyError)
+
+	// Tampered payloads are rejected.
+	payload.Metadata[MetadataEncryptionKeyID] = []byte("new")
+	require.ErrorContains(t, rotated.FromPayload(payload, &value), "unable to decrypt")
+
+	// Plaintext payloads are decoded as they are.
+	plain, err := converter.GetDefaultDataConverter().ToPayload("plain")
+	require.NoError(t, err)
+	var s string
+	require.NoError(t, rotated.FromPayload(plain, &s))
+	require.Equal(t, "plain", s)
+}
+
+func Test_LoadKeyFile(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	write := func(content string) string {
+		path := filepath.Join(t.TempDir(), "keys.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	keys, err := LoadKeyFile(write("activeKey: k2\nkeys:\n  k1: " + key + "\n  k2: " + key + "\n"))
+	require.NoError(t, err)
+	require.Equal(t, "k2", keys.ActiveKeyID)
+	require.Len(t, keys.keys, 2)
+
+	_, err = LoadKeyFile(write("activeKey: k3\nkeys:\n  k1: " + key + "\n"))
+	require.ErrorContains(t, err, `the active key "k3" is not defined`)
+	_, err = LoadKeyFile(write("activeKey: k1\nkeys:\n  k1: c2hvcnQ=\n"))
+	require.ErrorContains(t, err, "has 5 bytes, expected 32")
+	_, err = LoadKeyFile(write("activeKey: k1\nkeys:\n  k1: not base64\n"))
+	require.ErrorContains(t, err, "not base64 encoded")
+	_, err = LoadKeyFile(write("active: k1\n"))
+	require.ErrorContains(t, err, "field active not found")
+}
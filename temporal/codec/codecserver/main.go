@@ -0,0 +1,87 @@
+// codecserver decodes and encodes payloads for the Temporal UI and CLI, so authorised users can read the
+// item data the samples store encrypted, or in the claim check store, instead of in workflow histories.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"my-samples-go/temporal/codec"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/logging"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func main() {
+	listen := flag.String("listen", "127.0.0.1:8081", "address the HTTP server listens on")
+	authToken := flag.String("auth-token", os.Getenv("CODEC_SERVER_AUTH_TOKEN"), "bearer token the requests must carry, required unless -insecure (env CODEC_SERVER_AUTH_TOKEN)")
+	insecure := flag.Bool("insecure", false, "allow running without -auth-token, so every client can decode the payloads")
+	origins := flag.String("origins", "http://localhost:8233", "comma separated origins allowed to call the server from a browser, e.g. the Temporal UI")
+	generateKey := flag.Bool("generate-key", false, "print a new random key for the key file and exit")
+	// Only the codec and log settings are used, the server does not connect to Temporal.
+	cfg := config.New("")
+	cfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if *generateKey {
+		key, err := codec.GenerateKey()
+		if err != nil {
+			logging.Fatal("Unable to generate key", "error", err)
+		}
+		fmt.Println(key)
+		return
+	}
+	if err := cfg.Load(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	cfg.SetDefaultLogger()
+
+	// The same codecs in the same order as the data converter of the other binaries.
+	codecs, err := cfg.PayloadCodecs()
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	if len(codecs) == 0 {
+		logging.Fatal("Invalid configuration", "error", "-encryption-key-file or -claim-check-dir is required")
+	}
+	for _, payloadCodec := range codecs {
+		if encryption, ok := payloadCodec.(*codec.Codec); ok {
+			slog.Info("Active encryption key", "keyId", encryption.Keys.ActiveKeyID)
+		}
+	}
+	if *authToken == "" {
+		if !*insecure {
+			logging.Fatal("Invalid configuration", "error", "-auth-token is required, pass -insecure to run without authorisation")
+		}
+		slog.Warn("No -auth-token set, every client can decode the payloads")
+	}
+
+	srv := &http.Server{
+		Addr:              *listen,
+		Handler:           newServer(codecs, *authToken, strings.Split(*origins, ",")),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Unable to shut down HTTP server", "error", err)
+		}
+	}()
+
+	slog.Info("Codec server listening", "address", *listen)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logging.Fatal("HTTP server failed", "error", err)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"go.temporal.io/sdk/converter"
+)
+
+type server struct {
+	codec     http.Handler
+	authToken string   // bearer token the requests must carry, no authorisation if empty
+	origins   []string // origins allowed to call the server from a browser, e.g. the Temporal UI
+}
+
+// newServer returns the codec server's HTTP handler. It serves the remote codec protocol of the Temporal UI
+// and CLI with the codecs, in the order of the data converter:
+//
+//	POST /encode  encode the payloads, {"payloads": [...]}
+//	POST /decode  decode the payloads, {"payloads": [...]}
+func newServer(codecs []converter.PayloadCodec, authToken string, origins []string) http.Handler {
+	return &server{
+		codec:     converter.NewPayloadCodecHTTPHandler(codecs...),
+		authToken: authToken,
+		origins:   origins,
+	}
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" && s.allowedOrigin(origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization,Content-Type,X-Namespace")
+		w.Header().Set("Access-Control-Allow-Methods", "POST")
+		w.Header().Add("Vary", "Origin")
+	}
+	// The browser sends the preflight requests without credentials.
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !s.authorised(r) {
+		http.Error(w, "unauthorised", http.StatusUnauthorized)
+		return
+	}
+	s.codec.ServeHTTP(w, r)
+}
+
+func (s *server) allowedOrigin(origin string) bool {
+	for _, allowed := range s.origins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *server) authorised(r *http.Request) bool {
+	if s.authToken == "" {
+		return true
+	}
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) == 1
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"my-samples-go/temporal/codec"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func testKeys(t *testing.T) *codec.Keys {
+	keys, err := codec.NewKeys("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, codec.KeySize)})
+	require.NoError(t, err)
+	return keys
+}
+
+func serve(h http.Handler, method string, target string, body string, header http.Header) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_Server_Decode(t *testing.T) {
+	keys := testKeys(t)
+	payload, err := converter.NewCodecDataConverter(converter.GetDefaultDataConverter(), &codec.Codec{Keys: keys}).ToPayload("secret")
+	require.NoError(t, err)
+	body, err := protojson.Marshal(&commonpb.Payloads{Payloads: []*commonpb.Payload{payload}})
+	require.NoError(t, err)
+	h := newServer([]converter.PayloadCodec{&codec.Codec{Keys: keys}}, "token", []string{"http://localhost:8233"})
+
+	rec := serve(h, http.MethodPost, "/decode", string(body), http.Header{"Authorization": {"Bearer wrong"}})
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(h, http.MethodPost, "/decode", string(body), http.Header{
+		"Authorization": {"Bearer token"},
+		"Content-Type":  {"application/json"},
+		"Origin":        {"http://localhost:8233"},
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "http://localhost:8233", rec.Header().Get("Access-Control-Allow-Origin"))
+	var decoded commonpb.Payloads
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), &decoded))
+	require.Len(t, decoded.Payloads, 1)
+	var value string
+	require.NoError(t, converter.GetDefaultDataConverter().FromPayload(decoded.Payloads[0], &value))
+	require.Equal(t, "secret", value)
+}
+
+func Test_Server_CORS(t *testing.T) {
+	h := newServer([]converter.PayloadCodec{&codec.Codec{Keys: testKeys(t)}}, "token", []string{"http://localhost:8233"})
+
+	// Preflight requests are answered without credentials.
+	rec := serve(h, http.MethodOptions, "/decode", "", http.Header{"Origin": {"http://localhost:8233"}})
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Contains(t, rec.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+
+	rec = serve(h, http.MethodOptions, "/decode", "", http.Header{"Origin": {"http://evil.example"}})
+	require.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,377 @@
+// Package config loads the Temporal connection settings shared by all binaries of the samples.
+//
+// Settings are applied in this order, later ones win: the defaults of the binary, the YAML file given by
+// -config or TEMPORAL_CONFIG_FILE, the TEMPORAL_* environment variables and the command line flags.
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"my-samples-go/temporal/claimcheck"
+	"my-samples-go/temporal/codec"
+	"my-samples-go/temporal/logging"
+	"my-samples-go/temporal/tracing"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+const DefaultHostPort = "passthrough:///localhost:7233"
+
+// Config holds the settings to connect to a Temporal cluster.
+type Config struct {
+	HostPort  string    `yaml:"hostPort"`
+	Namespace string    `yaml:"namespace"`
+	TaskQueue string    `yaml:"taskQueue"`
+	Identity  string    `yaml:"identity"` // client and worker identity, the SDK default if empty
+	APIKey    string    `yaml:"apiKey"`   // enables TLS if no TLS settings are given
+	TLS       TLSConfig `yaml:"tls"`
+	LogLevel  string    `yaml:"logLevel"`  // debug, info, warn or error
+	LogFormat string    `yaml:"logFormat"` // text or json
+	// EncryptionKeyFile is the key file of the payload codec, payloads are not encrypted if empty.
+	EncryptionKeyFile string `yaml:"encryptionKeyFile"`
+	// ClaimCheckDir is the directory large payloads are stored in, payloads are kept in the history if empty.
+	ClaimCheckDir string `yaml:"claimCheckDir"`
+	// TracingEndpoint is the OTLP/HTTP endpoint the traces are exported to, nothing is traced if empty.
+	TracingEndpoint string `yaml:"tracingEndpoint"`
+	// BaseDataConverter is the data converter the codecs are applied to, the default one if nil. It is set by
+	// the binaries, not by the settings.
+	BaseDataConverter converter.DataConverter `yaml:"-"`
+	// MetricsHandler receives the SDK metrics and the custom metrics of the workflows, none are emitted if nil.
+	// It is set by the binaries that expose metrics.
+	MetricsHandler client.MetricsHandler `yaml:"-"`
+
+	tracer trace.Tracer // set by StartTracing
+
+	file  string // YAML file, from -config or TEMPORAL_CONFIG_FILE
+	fs    *flag.FlagSet
+	flags *Config // values of the command line flags, applied only if the flag was set
+}
+
+// TLSConfig holds the mTLS settings. TLS is enabled if any of them is set.
+type TLSConfig struct {
+	CertFile   string `yaml:"certFile"` // client certificate, requires KeyFile
+	KeyFile    string `yaml:"keyFile"`
+	CAFile     string `yaml:"caFile"` // CA to verify the server with, the system roots if empty
+	ServerName string `yaml:"serverName"`
+}
+
+func (t TLSConfig) enabled() bool {
+	return t != TLSConfig{}
+}
+
+// settings maps the command line flags and environment variables to the settings they set.
+var settings = []struct {
+	flag  string
+	env   string
+	field func(c *Config) *string
+}{
+	{"host", "TEMPORAL_ADDRESS", func(c *Config) *string { return &c.HostPort }},
+	{"namespace", "TEMPORAL_NAMESPACE", func(c *Config) *string { return &c.Namespace }},
+	{"task-queue", "TEMPORAL_TASK_QUEUE", func(c *Config) *string { return &c.TaskQueue }},
+	{"identity", "TEMPORAL_IDENTITY", func(c *Config) *string { return &c.Identity }},
+	{"api-key", "TEMPORAL_API_KEY", func(c *Config) *string { return &c.APIKey }},
+	{"tls-cert", "TEMPORAL_TLS_CERT", func(c *Config) *string { return &c.TLS.CertFile }},
+	{"tls-key", "TEMPORAL_TLS_KEY", func(c *Config) *string { return &c.TLS.KeyFile }},
+	{"tls-ca", "TEMPORAL_TLS_CA", func(c *Config) *string { return &c.TLS.CAFile }},
+	{"tls-server-name", "TEMPORAL_TLS_SERVER_NAME", func(c *Config) *string { return &c.TLS.ServerName }},
+	{"log-level", "TEMPORAL_LOG_LEVEL", func(c *Config) *string { return &c.LogLevel }},
+	{"log-format", "TEMPORAL_LOG_FORMAT", func(c *Config) *string { return &c.LogFormat }},
+	{"encryption-key-file", "TEMPORAL_ENCRYPTION_KEY_FILE", func(c *Config) *string { return &c.EncryptionKeyFile }},
+	{"claim-check-dir", "TEMPORAL_CLAIM_CHECK_DIR", func(c *Config) *string { return &c.ClaimCheckDir }},
+	{"tracing-endpoint", "TEMPORAL_TRACING_ENDPOINT", func(c *Config) *string { return &c.TracingEndpoint }},
+}
+
+// New returns a Config with the defaults for a local development server and the given task queue.
+func New(taskQueue string) *Config {
+	return &Config{
+		HostPort:  DefaultHostPort,
+		Namespace: client.DefaultNamespace,
+		TaskQueue: taskQueue,
+		LogLevel:  "info",
+		LogFormat: logging.FormatText,
+	}
+}
+
+// RegisterFlags registers the connection flags on fs. Flags that are set override all other sources.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	c.fs = fs
+	c.flags = &Config{}
+	fs.StringVar(&c.file, "config", "", "YAML file with the connection settings (env TEMPORAL_CONFIG_FILE)")
+	fs.StringVar(&c.flags.HostPort, "host", c.HostPort, "Temporal server host:port (env TEMPORAL_ADDRESS)")
+	fs.StringVar(&c.flags.Namespace, "namespace", c.Namespace, "Temporal namespace (env TEMPORAL_NAMESPACE)")
+	fs.StringVar(&c.flags.TaskQueue, "task-queue", c.TaskQueue, "task queue (env TEMPORAL_TASK_QUEUE)")
+	fs.StringVar(&c.flags.Identity, "identity", "", "client identity (env TEMPORAL_IDENTITY)")
+	fs.StringVar(&c.flags.APIKey, "api-key", "", "API key, e.g. for Temporal Cloud (env TEMPORAL_API_KEY)")
+	fs.StringVar(&c.flags.TLS.CertFile, "tls-cert", "", "mTLS client certificate file (env TEMPORAL_TLS_CERT)")
+	fs.StringVar(&c.flags.TLS.KeyFile, "tls-key", "", "mTLS client key file (env TEMPORAL_TLS_KEY)")
+	fs.StringVar(&c.flags.TLS.CAFile, "tls-ca", "", "CA certificate file to verify the server (env TEMPORAL_TLS_CA)")
+	fs.StringVar(&c.flags.TLS.ServerName, "tls-server-name", "", "server name to verify the server certificate with (env TEMPORAL_TLS_SERVER_NAME)")
+	fs.StringVar(&c.flags.LogLevel, "log-level", c.LogLevel, "log level: debug, info, warn or error (env TEMPORAL_LOG_LEVEL)")
+	fs.StringVar(&c.flags.LogFormat, "log-format", c.LogFormat, "log format: text or json (env TEMPORAL_LOG_FORMAT)")
+	fs.StringVar(&c.flags.EncryptionKeyFile, "encryption-key-file", "", "key file to encrypt the payloads with (env TEMPORAL_ENCRYPTION_KEY_FILE)")
+	fs.StringVar(&c.flags.ClaimCheckDir, "claim-check-dir", "", "directory to store large payloads in instead of the history (env TEMPORAL_CLAIM_CHECK_DIR)")
+	fs.StringVar(&c.flags.TracingEndpoint, "tracing-endpoint", "", "OTLP/HTTP endpoint to export traces to, e.g. http://localhost:4318 (env TEMPORAL_TRACING_ENDPOINT)")
+}
+
+// Load applies the config file, the environment and the flags set on the command line, and validates the result.
+// It must be called after the flags were parsed.
+func (c *Config) Load() error {
+	file := c.file
+	if file == "" {
+		file = os.Getenv("TEMPORAL_CONFIG_FILE")
+	}
+	if file != "" {
+		if err := c.loadFile(file); err != nil {
+			return err
+		}
+	}
+
+	for _, setting := range settings {
+		if value, set := os.LookupEnv(setting.env); set {
+			*setting.field(c) = value
+		}
+	}
+
+	if c.fs != nil {
+		c.fs.Visit(func(f *flag.Flag) {
+			for _, setting := range settings {
+				if f.Name == setting.flag {
+					*setting.field(c) = *setting.field(c.flags)
+				}
+			}
+		})
+	}
+	return c.Validate()
+}
+
+func (c *Config) loadFile(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("unable to open config file: %w", err)
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("unable to read config file %s: %w", file, err)
+	}
+	return nil
+}
+
+// Validate checks that the settings are complete and consistent.
+func (c *Config) Validate() error {
+	if c.HostPort == "" {
+		return errors.New("host is required")
+	}
+	if c.Namespace == "" {
+		return errors.New("namespace is required")
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		return errors.New("the TLS certificate and key must be set together")
+	}
+	if _, err := c.SlogLevel(); err != nil {
+		return err
+	}
+	if _, err := logging.NewHandler(os.Stderr, c.LogFormat, slog.LevelInfo); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SlogLevel returns the configured log level.
+func (c *Config) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q, valid levels are: debug, info, warn, error", c.LogLevel)
+	}
+	return level, nil
+}
+
+// SlogLogger returns the logger for the configured log level and format. It writes to stderr.
+// Invalid settings fall back to the defaults, Load reports them.
+func (c *Config) SlogLogger() *slog.Logger {
+	level, err := c.SlogLevel()
+	if err != nil {
+		level = slog.LevelInfo
+	}
+	handler, err := logging.NewHandler(os.Stderr, c.LogFormat, level)
+	if err != nil {
+		handler, _ = logging.NewHandler(os.Stderr, logging.FormatText, level)
+	}
+	return slog.New(handler)
+}
+
+// SetDefaultLogger makes SlogLogger the default logger of slog and of the log package.
+func (c *Config) SetDefaultLogger() {
+	slog.SetDefault(c.SlogLogger())
+}
+
+// Logger returns the SDK logger for the configured log level and format. It writes to stderr.
+func (c *Config) Logger() log.Logger {
+	return log.NewStructuredLogger(c.SlogLogger())
+}
+
+// ClientOptions returns the Temporal client options for the settings.
+func (c *Config) ClientOptions() (client.Options, error) {
+	options := client.Options{
+		HostPort:       c.HostPort,
+		Namespace:      c.Namespace,
+		Identity:       c.Identity,
+		Logger:         c.Logger(),
+		MetricsHandler: c.MetricsHandler,
+		// Propagates the request ID to the workflows, activities and signals, see logging.WithRequestID.
+		ContextPropagators: []workflow.ContextPropagator{logging.NewPropagator()},
+	}
+	if c.APIKey != "" {
+		options.Credentials = client.NewAPIKeyStaticCredentials(c.APIKey)
+	}
+	if c.TLS.enabled() || c.APIKey != "" {
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return client.Options{}, err
+		}
+		options.ConnectionOptions.TLS = tlsConfig
+	}
+	if c.tracer != nil {
+		options.Interceptors = append(options.Interceptors, tracing.NewInterceptor(c.tracer))
+	}
+	if c.EncryptionKeyFile != "" || c.ClaimCheckDir != "" || c.BaseDataConverter != nil {
+		dataConverter, err := c.DataConverter()
+		if err != nil {
+			return client.Options{}, err
+		}
+		options.DataConverter = dataConverter
+	}
+	return options, nil
+}
+
+// DataConverter returns the data converter for the settings: the base one, with its payloads encrypted
+// if an encryption key file is set, and the large ones stored if a claim check directory is set.
+func (c *Config) DataConverter() (converter.DataConverter, error) {
+	base := c.BaseDataConverter
+	if base == nil {
+		base = converter.GetDefaultDataConverter()
+	}
+	codecs, err := c.PayloadCodecs()
+	if err != nil {
+		return nil, err
+	}
+	if len(codecs) == 0 {
+		return base, nil
+	}
+	return converter.NewCodecDataConverter(base, codecs...), nil
+}
+
+// PayloadCodecs returns the codecs of DataConverter in the order it applies them, none if neither an encryption
+// key file nor a claim check directory is set.
+func (c *Config) PayloadCodecs() ([]converter.PayloadCodec, error) {
+	// The codecs encode last to first, so the stored blobs are encrypted too.
+	var codecs []converter.PayloadCodec
+	if c.ClaimCheckDir != "" {
+		store, err := c.ClaimCheckStore()
+		if err != nil {
+			return nil, err
+		}
+		codecs = append(codecs, claimcheck.NewCodec(store))
+	}
+	if c.EncryptionKeyFile != "" {
+		keys, err := codec.LoadKeyFile(c.EncryptionKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		codecs = append(codecs, &codec.Codec{Keys: keys})
+	}
+	return codecs, nil
+}
+
+// ClaimCheckStore returns the store of the large payloads, nil if no claim check directory is set.
+func (c *Config) ClaimCheckStore() (claimcheck.Store, error) {
+	if c.ClaimCheckDir == "" {
+		return nil, nil
+	}
+	store, err := claimcheck.NewFileStore(c.ClaimCheckDir)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// StartTracing starts exporting the traces of serviceName if a tracing endpoint is set. It must be called before
+// the client is created. The returned function exports the remaining spans, call it before exiting.
+func (c *Config) StartTracing(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	if c.TracingEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	provider, err := tracing.NewProvider(ctx, c.TracingEndpoint, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start tracing: %w", err)
+	}
+	c.tracer = provider.Tracer(tracing.InstrumentationName)
+	return provider.Shutdown, nil
+}
+
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: c.TLS.ServerName, MinVersion: tls.VersionTLS12}
+	if c.TLS.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load TLS client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if c.TLS.CAFile != "" {
+		pem, err := os.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read TLS CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS CA file %s", c.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+// Dial connects a Temporal client with the settings.
+func (c *Config) Dial() (client.Client, error) {
+	options, err := c.ClientOptions()
+	if err != nil {
+		return nil, err
+	}
+	return client.Dial(options)
+}
+
+// String describes the connection, without secrets.
+func (c *Config) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "host=%s namespace=%s taskQueue=%s", c.HostPort, c.Namespace, c.TaskQueue)
+	if c.TLS.enabled() || c.APIKey != "" {
+		b.WriteString(" tls=true")
+	}
+	if c.APIKey != "" {
+		b.WriteString(" apiKey=***")
+	}
+	if c.EncryptionKeyFile != "" {
+		b.WriteString(" encryption=true")
+	}
+	if c.ClaimCheckDir != "" {
+		b.WriteString(" claimCheck=true")
+	}
+	if c.TracingEndpoint != "" {
+		b.WriteString(" tracing=" + c.TracingEndpoint)
+	}
+	return b.String()
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"math/big"
+	"my-samples-go/temporal/claimcheck"
+	"my-samples-go/temporal/codec"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func load(t *testing.T, args ...string) (*Config, error) {
+	c := New("default-task-queue")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	c.RegisterFlags(fs)
+	require.NoError(t, fs.Parse(args))
+	return c, c.Load()
+}
+
+func Test_Config_Defaults(t *testing.T) {
+	c, err := load(t)
+	require.NoError(t, err)
+	require.Equal(t, DefaultHostPort, c.HostPort)
+	require.Equal(t, "default", c.Namespace)
+	require.Equal(t, "default-task-queue", c.TaskQueue)
+
+	options, err := c.ClientOptions()
+	require.NoError(t, err)
+	require.Nil(t, options.ConnectionOptions.TLS)
+	require.Nil(t, options.Credentials)
+}
+
+func Test_Config_Precedence(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+hostPort: file-host:7233
+namespace: file-namespace
+taskQueue: file-task-queue
+identity: file-identity
+logLevel: debug
+logFormat: json
+`)
+	t.Setenv("TEMPORAL_NAMESPACE", "env-namespace")
+	t.Setenv("TEMPORAL_TASK_QUEUE", "env-task-queue")
+
+	c, err := load(t, "-config", file, "-task-queue", "flag-task-queue")
+	require.NoError(t, err)
+	require.Equal(t, "file-host:7233", c.HostPort)
+	require.Equal(t, "env-namespace", c.Namespace)
+	require.Equal(t, "flag-task-queue", c.TaskQueue)
+	require.Equal(t, "file-identity", c.Identity)
+	require.Equal(t, "debug", c.LogLevel)
+	require.Equal(t, "json", c.LogFormat)
+
+	// The config file can also be given through the environment.
+	t.Setenv("TEMPORAL_CONFIG_FILE", file)
+	c, err = load(t)
+	require.NoError(t, err)
+	require.Equal(t, "file-host:7233", c.HostPort)
+}
+
+func Test_Config_Invalid(t *testing.T) {
+	_, err := load(t, "-log-level", "verbose")
+	require.ErrorContains(t, err, "invalid log level")
+
+	_, err = load(t, "-log-format", "xml")
+	require.ErrorContains(t, err, "invalid log format")
+
+	_, err = load(t, "-tls-cert", "client.pem")
+	require.ErrorContains(t, err, "must be set together")
+
+	_, err = load(t, "-config", writeFile(t, "config.yaml", "host: typo:7233\n"))
+	require.ErrorContains(t, err, "field host not found")
+}
+
+func Test_Config_TLSAndAPIKey(t *testing.T) {
+	certPEM, keyPEM := selfSignedCert(t)
+	certFile := writeFile(t, "client.pem", certPEM)
+	keyFile := writeFile(t, "client.key", keyPEM)
+
+	c, err := load(t, "-tls-cert", certFile, "-tls-key", keyFile, "-tls-ca", certFile, "-tls-server-name", "temporal.example")
+	require.NoError(t, err)
+	options, err := c.ClientOptions()
+	require.NoError(t, err)
+	require.NotNil(t, options.ConnectionOptions.TLS)
+	require.Len(t, options.ConnectionOptions.TLS.Certificates, 1)
+	require.NotNil(t, options.ConnectionOptions.TLS.RootCAs)
+	require.Equal(t, "temporal.example", options.ConnectionOptions.TLS.ServerName)
+
+	// An API key alone enables TLS with the system roots.
+	t.Setenv("TEMPORAL_API_KEY", "secret")
+	c, err = load(t)
+	require.NoError(t, err)
+	options, err = c.ClientOptions()
+	require.NoError(t, err)
+	require.NotNil(t, options.Credentials)
+	require.NotNil(t, options.ConnectionOptions.TLS)
+	require.NotContains(t, c.String(), "secret")
+}
+
+func Test_Config_EncryptionKeyFile(t *testing.T) {
+	key, err := codec.GenerateKey()
+	require.NoError(t, err)
+	keyFile := writeFile(t, "keys.yaml", "activeKey: k1\nkeys:\n  k1: "+key+"\n")
+
+	t.Setenv("TEMPORAL_ENCRYPTION_KEY_FILE", keyFile)
+	c, err := load(t)
+	require.NoError(t, err)
+	options, err := c.ClientOptions()
+	require.NoError(t, err)
+	payload, err := options.DataConverter.ToPayload("secret")
+	require.NoError(t, err)
+	require.Equal(t, codec.MetadataEncodingEncrypted, string(payload.Metadata["encoding"]))
+	require.Contains(t, c.String(), "encryption=true")
+
+	c, err = load(t, "-encryption-key-file", filepath.Join(t.TempDir(), "missing.yaml"))
+	require.NoError(t, err)
+	_, err = c.ClientOptions()
+	require.ErrorContains(t, err, "unable to open key file")
+}
+
+func Test_Config_ClaimCheckDir(t *testing.T) {
+	key, err := codec.GenerateKey()
+	require.NoError(t, err)
+	keyFile := writeFile(t, "keys.yaml", "activeKey: k1\nkeys:\n  k1: "+key+"\n")
+	dir := t.TempDir()
+
+	c, err := load(t, "-claim-check-dir", dir, "-encryption-key-file", keyFile)
+	require.NoError(t, err)
+	dc, err := c.DataConverter()
+	require.NoError(t, err)
+	large := strings.Repeat("secret", claimcheck.DefaultThreshold)
+	payload, err := dc.ToPayload(large)
+	require.NoError(t, err)
+	require.Equal(t, claimcheck.MetadataEncodingClaimCheck, string(payload.Metadata["encoding"]))
+
+	// The stored blob is encrypted.
+	blob, err := os.ReadFile(filepath.Join(dir, string(payload.Data)))
+	require.NoError(t, err)
+	require.NotContains(t, string(blob), "secret")
+	var value string
+	require.NoError(t, dc.FromPayload(payload, &value))
+	require.Equal(t, large, value)
+}
+
+func Test_Config_TracingEndpoint(t *testing.T) {
+	c, err := load(t)
+	require.NoError(t, err)
+	shutdown, err := c.StartTracing(context.Background(), "test")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+	options, err := c.ClientOptions()
+	require.NoError(t, err)
+	require.Empty(t, options.Interceptors)
+
+	t.Setenv("TEMPORAL_TRACING_ENDPOINT", "http://localhost:4318")
+	c, err = load(t)
+	require.NoError(t, err)
+	shutdown, err = c.StartTracing(context.Background(), "test")
+	require.NoError(t, err)
+	defer shutdown(context.Background())
+	options, err = c.ClientOptions()
+	require.NoError(t, err)
+	require.Len(t, options.Interceptors, 1)
+	require.Contains(t, c.String(), "tracing=http://localhost:4318")
+}
+
+func selfSignedCert(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
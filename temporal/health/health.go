@@ -0,0 +1,316 @@
+// Package health serves the liveness and readiness probes of the workers, and shuts them down gracefully.
+//
+//	GET /livez   200 unless the worker failed
+//	GET /readyz  200 while the worker runs, reaches the Temporal server and polls its task queue
+//	GET /info    the worker identity, task queue and build info
+//
+// All of them answer with a Status. On SIGINT or SIGTERM the worker reports not ready for the drain period,
+// so the orchestrator running it stops counting on it, and is then stopped.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"my-samples-go/temporal/config"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+// checkTimeout bounds the calls to the Temporal server of a readiness check.
+const checkTimeout = 5 * time.Second
+
+// Options configure the health endpoint and the shutdown of a worker.
+type Options struct {
+	Listen      string        // address of the health endpoint, disabled if empty
+	Drain       time.Duration // time the worker reports not ready before it is stopped
+	StopTimeout time.Duration // time the running activities are given to finish once the worker is stopped
+}
+
+// RegisterFlags registers the health and shutdown flags on fs, with the defaults of o.
+func (o *Options) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Listen, "health-listen", o.Listen, "address of the health endpoint (/livez, /readyz, /info), disabled if empty")
+	fs.DurationVar(&o.Drain, "shutdown-drain", o.Drain, "time the worker reports not ready on shutdown before it stops polling")
+	fs.DurationVar(&o.StopTimeout, "shutdown-timeout", o.StopTimeout, "time the running activities are given to finish once the worker stops")
+}
+
+// Phases of a worker.
+const (
+	PhaseStarting = "starting"
+	PhaseRunning  = "running"
+	PhaseDraining = "draining"
+	PhaseStopped  = "stopped"
+	PhaseFailed   = "failed"
+)
+
+// Status is the body of all health responses.
+type Status struct {
+	Status    string            `json:"status"` // ok or failing
+	Phase     string            `json:"phase"`
+	Checks    map[string]string `json:"checks,omitempty"` // readiness checks by name, ok or the reason they fail
+	Identity  string            `json:"identity"`
+	Namespace string            `json:"namespace"`
+	TaskQueue string            `json:"taskQueue"`
+	Build     Build             `json:"build"`
+}
+
+// Build describes the binary of the worker.
+type Build struct {
+	Path      string `json:"path,omitempty"` // main package
+	Version   string `json:"version,omitempty"`
+	GoVersion string `json:"goVersion,omitempty"`
+	Revision  string `json:"revision,omitempty"` // VCS revision, with "-dirty" if the tree was modified
+	Time      string `json:"time,omitempty"`     // VCS commit time
+}
+
+// ReadBuild returns the build info embedded in the binary.
+func ReadBuild() Build {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Build{}
+	}
+	build := Build{Path: info.Path, Version: info.Main.Version, GoVersion: info.GoVersion}
+	modified := false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if modified && build.Revision != "" {
+		build.Revision += "-dirty"
+	}
+	return build
+}
+
+// Server serves the health endpoint of a worker and runs it.
+type Server struct {
+	Options
+	client    client.Client
+	namespace string
+	taskQueue string
+	identity  string
+	build     Build
+
+	mu     sync.Mutex
+	phase  string
+	err    error         // why the worker failed
+	failed chan struct{} // closed when the worker failed
+}
+
+// New returns the health server of the worker polling the task queue of cfg with the client c.
+func New(c client.Client, cfg *config.Config, options Options) *Server {
+	identity := cfg.Identity
+	if identity == "" {
+		// The default identity of the SDK, set explicitly to find the worker among the pollers.
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "Unknown"
+		}
+		identity = fmt.Sprintf("%d@%s@", os.Getpid(), hostname)
+	}
+	return &Server{
+		Options:   options,
+		client:    c,
+		namespace: cfg.Namespace,
+		taskQueue: cfg.TaskQueue,
+		identity:  identity,
+		build:     ReadBuild(),
+		phase:     PhaseStarting,
+		failed:    make(chan struct{}),
+	}
+}
+
+// WorkerOptions returns options with the identity, stop timeout and fatal error callback the server relies on.
+func (s *Server) WorkerOptions(options worker.Options) worker.Options {
+	options.Identity = s.identity
+	options.WorkerStopTimeout = s.StopTimeout
+	options.OnFatalError = s.fail
+	return options
+}
+
+func (s *Server) setPhase(phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.phase != PhaseFailed {
+		s.phase = phase
+	}
+}
+
+func (s *Server) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.phase == PhaseFailed {
+		return
+	}
+	s.phase = PhaseFailed
+	s.err = err
+	close(s.failed)
+}
+
+func (s *Server) state() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.phase, s.err
+}
+
+// Handler returns the handler of the health endpoint.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /livez", s.live)
+	mux.HandleFunc("GET /readyz", s.ready)
+	mux.HandleFunc("GET /info", s.info)
+	return mux
+}
+
+func (s *Server) status(phase string) Status {
+	return Status{Status: "ok", Phase: phase, Identity: s.identity, Namespace: s.namespace, TaskQueue: s.taskQueue, Build: s.build}
+}
+
+// live fails only if the worker failed, a restart does not help against an unreachable server.
+func (s *Server) live(w http.ResponseWriter, r *http.Request) {
+	phase, err := s.state()
+	status := s.status(phase)
+	if phase == PhaseFailed {
+		status.Status = "failing"
+		status.Checks = map[string]string{"worker": err.Error()}
+	}
+	writeStatus(w, status)
+}
+
+func (s *Server) ready(w http.ResponseWriter, r *http.Request) {
+	phase, err := s.state()
+	status := s.status(phase)
+	status.Checks = map[string]string{"worker": "ok"}
+	switch phase {
+	case PhaseRunning:
+		// The task queue is only checked once the server is reachable.
+		ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+		defer cancel()
+		status.Checks["server"] = "ok"
+		if _, err := s.client.CheckHealth(ctx, &client.CheckHealthRequest{}); err != nil {
+			status.Checks["server"] = err.Error()
+			break
+		}
+		status.Checks["polling"] = "ok"
+		if err := s.checkPolling(ctx); err != nil {
+			status.Checks["polling"] = err.Error()
+		}
+	case PhaseFailed:
+		status.Checks["worker"] = err.Error()
+	default:
+		status.Checks["worker"] = "worker is " + phase
+	}
+	for _, check := range status.Checks {
+		if check != "ok" {
+			status.Status = "failing"
+		}
+	}
+	writeStatus(w, status)
+}
+
+// checkPolling fails unless the worker is among the recent pollers of its workflow task queue.
+func (s *Server) checkPolling(ctx context.Context) error {
+	resp, err := s.client.DescribeTaskQueue(ctx, s.taskQueue, enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+	if err != nil {
+		return err
+	}
+	polling := slices.ContainsFunc(resp.GetPollers(), func(poller *taskqueuepb.PollerInfo) bool {
+		return poller.GetIdentity() == s.identity
+	})
+	if !polling {
+		return fmt.Errorf("not polling task queue %s", s.taskQueue)
+	}
+	return nil
+}
+
+func (s *Server) info(w http.ResponseWriter, r *http.Request) {
+	phase, _ := s.state()
+	writeStatus(w, s.status(phase))
+}
+
+func writeStatus(w http.ResponseWriter, status Status) {
+	w.Header().Set("Content-Type", "application/json")
+	if status.Status == "ok" {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		slog.Warn("Unable to write response", "error", err)
+	}
+}
+
+// InterruptCh returns the channel SIGINT and SIGTERM are delivered to. Unlike worker.InterruptCh, it delivers
+// every signal, so a second one can cut the drain period short.
+func InterruptCh() <-chan os.Signal {
+	ch := make(chan os.Signal, 2)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	return ch
+}
+
+// Run starts the worker and the health endpoint, and runs until a signal is received on interrupt, or until the
+// worker fails. On a signal, the worker reports not ready for the drain period, or until the next signal, before it
+// is stopped.
+func (s *Server) Run(w worker.Worker, interrupt <-chan os.Signal) error {
+	if s.Listen != "" {
+		// Listen before starting the worker, so probes never miss a running worker.
+		listener, err := net.Listen("tcp", s.Listen)
+		if err != nil {
+			return fmt.Errorf("unable to serve worker health: %w", err)
+		}
+		srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			slog.Info("Serving worker health", "address", listener.Addr().String())
+			if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Unable to serve worker health", "error", err)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	if err := w.Start(); err != nil {
+		s.fail(err)
+		return err
+	}
+	s.setPhase(PhaseRunning)
+
+	select {
+	case <-interrupt:
+	case <-s.failed:
+		// The SDK stops the worker itself.
+		_, err := s.state()
+		return err
+	}
+
+	s.setPhase(PhaseDraining)
+	slog.Info("Draining worker before stopping it", "drain", s.Drain)
+	select {
+	case <-time.After(s.Drain):
+	case <-interrupt:
+		slog.Info("Interrupted again, stopping worker now")
+	}
+	w.Stop()
+	s.setPhase(PhaseStopped)
+	slog.Info("Worker stopped")
+	return nil
+}
@@ -0,0 +1,143 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"my-samples-go/temporal/config"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/worker"
+)
+
+func newTestServer(t *testing.T, options Options) (*mocks.Client, *Server) {
+	c := mocks.NewClient(t)
+	cfg := config.New("test-task-queue")
+	cfg.Identity = "worker-1"
+	return c, New(c, cfg, options)
+}
+
+func probe(t *testing.T, s *Server, path string) (int, Status) {
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var status Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	return rec.Code, status
+}
+
+func expectPollers(c *mocks.Client, identities ...string) *mock.Call {
+	var pollers []*taskqueuepb.PollerInfo
+	for _, identity := range identities {
+		pollers = append(pollers, &taskqueuepb.PollerInfo{Identity: identity})
+	}
+	return c.On("DescribeTaskQueue", mock.Anything, "test-task-queue", enumspb.TASK_QUEUE_TYPE_WORKFLOW).
+		Return(&workflowservice.DescribeTaskQueueResponse{Pollers: pollers}, nil).Once()
+}
+
+func Test_Server_Probes(t *testing.T) {
+	c, s := newTestServer(t, Options{})
+	require.Equal(t, "worker-1", s.WorkerOptions(worker.Options{}).Identity)
+
+	code, status := probe(t, s, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "worker is starting", status.Checks["worker"])
+
+	s.setPhase(PhaseRunning)
+	c.On("CheckHealth", mock.Anything, mock.Anything).Return(&client.CheckHealthResponse{}, nil).Times(2)
+	expectPollers(c, "other-worker", "worker-1")
+	code, status = probe(t, s, "/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, map[string]string{"worker": "ok", "server": "ok", "polling": "ok"}, status.Checks)
+	require.Equal(t, "worker-1", status.Identity)
+	require.Equal(t, "test-task-queue", status.TaskQueue)
+
+	expectPollers(c, "other-worker")
+	code, status = probe(t, s, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "not polling task queue test-task-queue", status.Checks["polling"])
+
+	c.On("CheckHealth", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	code, status = probe(t, s, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "connection refused", status.Checks["server"])
+	// An unreachable server does not make the worker dead.
+	code, _ = probe(t, s, "/livez")
+	require.Equal(t, http.StatusOK, code)
+
+	s.setPhase(PhaseDraining)
+	code, status = probe(t, s, "/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, PhaseDraining, status.Phase)
+
+	s.fail(errors.New("namespace not found"))
+	code, status = probe(t, s, "/livez")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "namespace not found", status.Checks["worker"])
+	code, status = probe(t, s, "/info")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, PhaseFailed, status.Phase)
+}
+
+// fakeWorker records the calls of the server.
+type fakeWorker struct {
+	worker.Worker
+	started chan struct{}
+	stopped chan struct{}
+}
+
+func (w *fakeWorker) Start() error {
+	close(w.started)
+	return nil
+}
+
+func (w *fakeWorker) Stop() {
+	close(w.stopped)
+}
+
+func Test_Server_Run(t *testing.T) {
+	_, s := newTestServer(t, Options{Drain: time.Hour})
+	w := &fakeWorker{started: make(chan struct{}), stopped: make(chan struct{})}
+	interrupt := make(chan os.Signal, 2)
+	done := make(chan error)
+	go func() { done <- s.Run(w, interrupt) }()
+
+	<-w.started
+	interrupt <- os.Interrupt
+	require.Eventually(t, func() bool {
+		phase, _ := s.state()
+		return phase == PhaseDraining
+	}, time.Second, time.Millisecond)
+	select {
+	case <-w.stopped:
+		t.Fatal("the worker was stopped before the drain period ended")
+	default:
+	}
+
+	// A second signal ends the drain period.
+	interrupt <- os.Interrupt
+	require.NoError(t, <-done)
+	<-w.stopped
+	phase, _ := s.state()
+	require.Equal(t, PhaseStopped, phase)
+}
+
+func Test_Server_Run_WorkerFails(t *testing.T) {
+	_, s := newTestServer(t, Options{Listen: "127.0.0.1:0"})
+	w := &fakeWorker{started: make(chan struct{}), stopped: make(chan struct{})}
+	done := make(chan error)
+	go func() { done <- s.Run(w, make(chan os.Signal)) }()
+
+	<-w.started
+	s.WorkerOptions(worker.Options{}).OnFatalError(errors.New("namespace not found"))
+	require.ErrorContains(t, <-done, "namespace not found")
+}
@@ -21,7 +21,7 @@ func Workflow(ctx workflow.Context, name string) (string, error) {
 	var result string
 	err := workflow.ExecuteActivity(ctx, Activity, name).Get(ctx, &result)
 	if err != nil {
-		logger.Error("Activity failed.", "Error", err)
+		logger.Error("Activity failed.", "error", err)
 		return "", err
 	}
 
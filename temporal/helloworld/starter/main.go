@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
-	"log"
+	"flag"
+	"log/slog"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/logging"
 
 	"go.temporal.io/sdk/client"
 
@@ -10,32 +13,38 @@ import (
 )
 
 func main() {
+	cfg := config.New("hello-world")
+	cfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	if err := cfg.Load(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	cfg.SetDefaultLogger()
+
 	// The client is a heavyweight object that should be created once per process.
-	c, err := client.Dial(client.Options{
-		HostPort: "passthrough:///localhost:7233",
-	})
+	c, err := cfg.Dial()
 	if err != nil {
-		log.Fatalln("Unable to create client", err)
+		logging.Fatal("Unable to create client", "error", err)
 	}
 	defer c.Close()
 
 	workflowOptions := client.StartWorkflowOptions{
 		ID:        "hello_world_workflowID",
-		TaskQueue: "hello-world",
+		TaskQueue: cfg.TaskQueue,
 	}
 
 	we, err := c.ExecuteWorkflow(context.Background(), workflowOptions, helloworld.Workflow, "Temporal")
 	if err != nil {
-		log.Fatalln("Unable to execute workflow", err)
+		logging.Fatal("Unable to execute workflow", "error", err)
 	}
 
-	log.Println("Started workflow", "WorkflowID", we.GetID(), "RunID", we.GetRunID())
+	slog.Info("Started workflow", "WorkflowID", we.GetID(), "RunID", we.GetRunID())
 
 	// Synchronously wait for the workflow completion.
 	var result string
 	err = we.Get(context.Background(), &result)
 	if err != nil {
-		log.Fatalln("Unable get workflow result", err)
+		logging.Fatal("Unable get workflow result", "error", err)
 	}
-	log.Println("Workflow result:", result)
+	slog.Info("Workflow result", "result", result)
 }
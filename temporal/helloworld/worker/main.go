@@ -1,31 +1,46 @@
 package main
 
 import (
-	"log"
+	"flag"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/health"
+	"my-samples-go/temporal/logging"
+	"time"
 
-	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/worker"
 
 	"github.com/temporalio/samples-go/helloworld"
 )
 
 func main() {
+	cfg := config.New("hello-world")
+	cfg.RegisterFlags(flag.CommandLine)
+	healthOptions := health.Options{Listen: ":8092", Drain: 10 * time.Second, StopTimeout: 30 * time.Second}
+	healthOptions.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	if err := cfg.Load(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	cfg.SetDefaultLogger()
+
 	// The client and worker are heavyweight objects that should be created once per process.
-	c, err := client.Dial(client.Options{
-		HostPort: "passthrough:///localhost:7233",
-	})
+	c, err := cfg.Dial()
 	if err != nil {
-		log.Fatalln("Unable to create client", err)
+		logging.Fatal("Unable to create client", "error", err)
 	}
 	defer c.Close()
 
-	w := worker.New(c, "hello-world", worker.Options{})
+	healthServer := health.New(c, cfg, healthOptions)
+	w := worker.New(c, cfg.TaskQueue, healthServer.WorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{logging.NewInterceptor(nil)},
+	}))
 
 	w.RegisterWorkflow(helloworld.Workflow)
 	w.RegisterActivity(helloworld.Activity)
 
-	err = w.Run(worker.InterruptCh())
+	err = healthServer.Run(w, health.InterruptCh())
 	if err != nil {
-		log.Fatalln("Unable to start worker", err)
+		logging.Fatal("Unable to start worker", "error", err)
 	}
 }
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+// RequestIDHeader is the Temporal header the request ID is propagated in.
+const RequestIDHeader = "request-id"
+
+type requestIDKey struct{}
+
+// NewRequestID returns a new random request ID.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// WithRequestID returns ctx with the request ID id. The workflows, activities and signals started with the
+// context carry it too, if the client uses the propagator of NewPropagator.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID of ctx, empty if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WorkflowRequestID returns the request ID the workflow, or the signal ctx was returned for by SignalContext,
+// was started with, empty if there is none.
+func WorkflowRequestID(ctx workflow.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// NewPropagator returns the context propagator of the request ID. Set it on the client options.
+func NewPropagator() workflow.ContextPropagator {
+	return requestIDPropagator{}
+}
+
+type requestIDPropagator struct{}
+
+func (requestIDPropagator) Inject(ctx context.Context, writer workflow.HeaderWriter) error {
+	return injectRequestID(RequestID(ctx), writer)
+}
+
+func (requestIDPropagator) InjectFromWorkflow(ctx workflow.Context, writer workflow.HeaderWriter) error {
+	return injectRequestID(WorkflowRequestID(ctx), writer)
+}
+
+func (requestIDPropagator) Extract(ctx context.Context, reader workflow.HeaderReader) (context.Context, error) {
+	id, err := extractRequestID(reader)
+	if err != nil || id == "" {
+		return ctx, err
+	}
+	return WithRequestID(ctx, id), nil
+}
+
+func (requestIDPropagator) ExtractToWorkflow(ctx workflow.Context, reader workflow.HeaderReader) (workflow.Context, error) {
+	id, err := extractRequestID(reader)
+	if err != nil || id == "" {
+		return ctx, err
+	}
+	return workflow.WithValue(ctx, requestIDKey{}, id), nil
+}
+
+func injectRequestID(id string, writer workflow.HeaderWriter) error {
+	if id == "" {
+		return nil
+	}
+	payload, err := converter.GetDefaultDataConverter().ToPayload(id)
+	if err != nil {
+		return err
+	}
+	writer.Set(RequestIDHeader, payload)
+	return nil
+}
+
+func extractRequestID(reader workflow.HeaderReader) (string, error) {
+	payload, _ := reader.Get(RequestIDHeader)
+	return decodeRequestID(payload)
+}
+
+// decodeRequestID decodes the request ID header, empty if payload is nil.
+func decodeRequestID(payload *commonpb.Payload) (string, error) {
+	if payload == nil {
+		return "", nil
+	}
+	var id string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
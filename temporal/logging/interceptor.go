@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"context"
+
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/workflow"
+)
+
+// FieldsFunc returns the attributes attached to all log lines of a workflow, from its context and arguments.
+type FieldsFunc func(ctx workflow.Context, args []interface{}) []interface{}
+
+// NewInterceptor returns the worker interceptor that attaches the attributes of fields, those added with With and
+// the request ID to the log lines of the workflows, and the request ID to the log lines of the activities.
+// fields may be nil.
+func NewInterceptor(fields FieldsFunc) interceptor.WorkerInterceptor {
+	return &workerInterceptor{fields: fields}
+}
+
+type fieldsKey struct{}
+
+// With returns ctx with keyvals attached to the log lines of workflow.GetLogger(ctx).
+func With(ctx workflow.Context, keyvals ...interface{}) workflow.Context {
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+	return workflow.WithValue(ctx, fieldsKey{}, append(fields[:len(fields):len(fields)], keyvals...))
+}
+
+type workerInterceptor struct {
+	interceptor.WorkerInterceptorBase
+	fields FieldsFunc
+}
+
+func (w *workerInterceptor) InterceptWorkflow(ctx workflow.Context, next interceptor.WorkflowInboundInterceptor) interceptor.WorkflowInboundInterceptor {
+	return &workflowInboundInterceptor{
+		WorkflowInboundInterceptorBase: interceptor.WorkflowInboundInterceptorBase{Next: next},
+		fields:                         w.fields,
+		signalRequestIDs:               signalRequestIDs{},
+	}
+}
+
+func (w *workerInterceptor) InterceptActivity(ctx context.Context, next interceptor.ActivityInboundInterceptor) interceptor.ActivityInboundInterceptor {
+	return &activityInboundInterceptor{ActivityInboundInterceptorBase: interceptor.ActivityInboundInterceptorBase{Next: next}}
+}
+
+type signalRequestIDsKey struct{}
+
+// signalRequestIDs holds the request IDs of the received signals by signal name, oldest first.
+type signalRequestIDs map[string][]string
+
+type workflowInboundInterceptor struct {
+	interceptor.WorkflowInboundInterceptorBase
+	fields           FieldsFunc
+	signalRequestIDs signalRequestIDs
+}
+
+func (w *workflowInboundInterceptor) Init(outbound interceptor.WorkflowOutboundInterceptor) error {
+	return w.Next.Init(&workflowOutboundInterceptor{WorkflowOutboundInterceptorBase: interceptor.WorkflowOutboundInterceptorBase{Next: outbound}})
+}
+
+func (w *workflowInboundInterceptor) ExecuteWorkflow(ctx workflow.Context, in *interceptor.ExecuteWorkflowInput) (interface{}, error) {
+	ctx = workflow.WithValue(ctx, signalRequestIDsKey{}, w.signalRequestIDs)
+	if w.fields != nil {
+		ctx = With(ctx, w.fields(ctx, in.Args)...)
+	}
+	return w.Next.ExecuteWorkflow(ctx, in)
+}
+
+func (w *workflowInboundInterceptor) HandleSignal(ctx workflow.Context, in *interceptor.HandleSignalInput) error {
+	// The request ID of ctx falls back to the workflow's, so it is read from the header of the signal itself.
+	id, _ := decodeRequestID(interceptor.WorkflowHeader(ctx)[RequestIDHeader])
+	w.signalRequestIDs[in.SignalName] = append(w.signalRequestIDs[in.SignalName], id)
+	return w.Next.HandleSignal(ctx, in)
+}
+
+// SignalContext returns ctx with the request ID of the oldest signal received on the channel signalName that was
+// not claimed yet, so the log lines of the work the signal causes carry the request of its sender. Call it once for
+// every signal received from the channel. Without the interceptor, ctx is returned unchanged.
+func SignalContext(ctx workflow.Context, signalName string) workflow.Context {
+	ids, intercepted := ctx.Value(signalRequestIDsKey{}).(signalRequestIDs)
+	if !intercepted || len(ids[signalName]) == 0 {
+		return ctx
+	}
+	id := ids[signalName][0]
+	ids[signalName] = ids[signalName][1:]
+	return workflow.WithValue(ctx, requestIDKey{}, id)
+}
+
+type workflowOutboundInterceptor struct {
+	interceptor.WorkflowOutboundInterceptorBase
+}
+
+func (w *workflowOutboundInterceptor) GetLogger(ctx workflow.Context) log.Logger {
+	fields, _ := ctx.Value(fieldsKey{}).([]interface{})
+	if id := WorkflowRequestID(ctx); id != "" {
+		fields = append(fields[:len(fields):len(fields)], RequestIDKey, id)
+	}
+	return withFields(w.Next.GetLogger(ctx), fields)
+}
+
+type activityInboundInterceptor struct {
+	interceptor.ActivityInboundInterceptorBase
+}
+
+func (a *activityInboundInterceptor) Init(outbound interceptor.ActivityOutboundInterceptor) error {
+	return a.Next.Init(&activityOutboundInterceptor{ActivityOutboundInterceptorBase: interceptor.ActivityOutboundInterceptorBase{Next: outbound}})
+}
+
+type activityOutboundInterceptor struct {
+	interceptor.ActivityOutboundInterceptorBase
+}
+
+func (a *activityOutboundInterceptor) GetLogger(ctx context.Context) log.Logger {
+	var fields []interface{}
+	if id := RequestID(ctx); id != "" {
+		fields = append(fields, RequestIDKey, id)
+	}
+	return withFields(a.Next.GetLogger(ctx), fields)
+}
+
+func withFields(logger log.Logger, fields []interface{}) log.Logger {
+	if len(fields) == 0 {
+		return logger
+	}
+	return log.With(logger, fields...)
+}
@@ -0,0 +1,68 @@
+// Package logging sets up the slog based logging of the samples, and attaches the context of the work being
+// done, e.g. the item and the request, to the log lines of the clients, workflows and activities.
+//
+// Log lines use the keys declared here, so they can be filtered consistently across binaries.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// Keys of the log attributes.
+const (
+	ItemIDKey            = "itemID"
+	ItemTypeKey          = "itemType"
+	ItemWorkflowIDKey    = "itemWorkflowID"
+	OrchestratorRunIDKey = "orchestratorRunID"
+	RequestIDKey         = "requestID"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// NewHandler returns the handler writing log lines of at least level to w in format. The request ID of the
+// context the lines are logged with is attached to them, see WithRequestID.
+func NewHandler(w io.Writer, format string, level slog.Leveler) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level}
+	switch format {
+	case FormatText:
+		return &contextHandler{Handler: slog.NewTextHandler(w, options)}, nil
+	case FormatJSON:
+		return &contextHandler{Handler: slog.NewJSONHandler(w, options)}, nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q, valid formats are: %s, %s", format, FormatText, FormatJSON)
+	}
+}
+
+// contextHandler adds the request ID of the context to the records.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Fatal logs msg at error level with the default logger and exits.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
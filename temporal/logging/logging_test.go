@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// logLines parses the JSON log lines of buf by message.
+func logLines(t *testing.T, buf *bytes.Buffer) map[string]map[string]interface{} {
+	lines := map[string]map[string]interface{}{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var line map[string]interface{}
+		require.NoError(t, dec.Decode(&line))
+		lines[line["msg"].(string)] = line
+	}
+	return lines
+}
+
+func Test_NewHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := NewHandler(&buf, FormatJSON, slog.LevelInfo)
+	require.NoError(t, err)
+	logger := slog.New(handler)
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-1"), "with request")
+	logger.With(ItemIDKey, "item-1").Info("without request")
+	logger.Debug("below level")
+
+	lines := logLines(t, &buf)
+	require.Len(t, lines, 2)
+	require.Equal(t, "req-1", lines["with request"][RequestIDKey])
+	require.Equal(t, "item-1", lines["without request"][ItemIDKey])
+	require.NotContains(t, lines["without request"], RequestIDKey)
+
+	_, err = NewHandler(&buf, "xml", slog.LevelInfo)
+	require.ErrorContains(t, err, "invalid log format")
+}
+
+type headerWriter map[string]*commonpb.Payload
+
+func (h headerWriter) Set(key string, value *commonpb.Payload) { h[key] = value }
+
+// greet logs in the workflow, in an activity and for every signal received on "greetings".
+func greet(ctx workflow.Context, name string) error {
+	ctx = With(ctx, "name", name)
+	workflow.GetLogger(ctx).Info("workflow")
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	if err := workflow.ExecuteActivity(ctx, greetActivity).Get(ctx, nil); err != nil {
+		return err
+	}
+
+	var greeting string
+	workflow.GetSignalChannel(ctx, "greetings").Receive(ctx, &greeting)
+	workflow.GetLogger(SignalContext(ctx, "greetings")).Info(greeting)
+	return nil
+}
+
+func greetActivity(ctx context.Context) error {
+	activity.GetLogger(ctx).Info("activity")
+	return nil
+}
+
+func Test_Interceptor(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := NewHandler(&buf, FormatJSON, slog.LevelInfo)
+	require.NoError(t, err)
+
+	header := headerWriter{}
+	require.NoError(t, NewPropagator().Inject(WithRequestID(context.Background(), "req-1"), header))
+
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetLogger(log.NewStructuredLogger(slog.New(handler)))
+	testSuite.SetContextPropagators([]workflow.ContextPropagator{NewPropagator()})
+	testSuite.SetHeader(&commonpb.Header{Fields: header})
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.SetWorkerOptions(worker.Options{Interceptors: []interceptor.WorkerInterceptor{NewInterceptor(
+		func(ctx workflow.Context, args []interface{}) []interface{} {
+			return []interface{}{ItemIDKey, args[0]}
+		},
+	)}})
+	env.RegisterWorkflow(greet)
+	env.RegisterActivity(greetActivity)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow("greetings", "signal")
+	}, time.Second)
+
+	env.ExecuteWorkflow(greet, "item-1")
+	require.NoError(t, env.GetWorkflowError())
+
+	lines := logLines(t, &buf)
+	require.Subset(t, lines["workflow"], map[string]interface{}{ItemIDKey: "item-1", "name": "item-1", RequestIDKey: "req-1"})
+	require.Subset(t, lines["activity"], map[string]interface{}{RequestIDKey: "req-1"})
+	// The signal was sent without a request ID, so it does not claim the request of the workflow.
+	require.Subset(t, lines["signal"], map[string]interface{}{ItemIDKey: "item-1"})
+	require.NotContains(t, lines["signal"], RequestIDKey)
+}
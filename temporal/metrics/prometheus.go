@@ -0,0 +1,165 @@
+// Package metrics exposes the metrics of the Temporal SDK, and the custom metrics the sample workflows emit
+// through workflow.GetMetricsHandler, to Prometheus.
+package metrics
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.temporal.io/sdk/client"
+)
+
+// TimerBuckets are the histogram buckets of the timers, in seconds. They go up to ten minutes,
+// since the item workflows process for far longer than a request takes.
+var TimerBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// Registry collects the metrics emitted through its handlers.
+//
+// The SDK emits the same metric with different tags, e.g. temporal_request with and without the failure
+// reason, which a Prometheus metric vector with fixed label names cannot hold. Every combination of name
+// and tags is therefore kept as its own metric, and the registry is an unchecked collector.
+type Registry struct {
+	mu      sync.Mutex
+	metrics map[string]prometheus.Collector // by key
+}
+
+var _ prometheus.Collector = (*Registry)(nil)
+
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]prometheus.Collector)}
+}
+
+// Describe sends no descriptors, which makes the registry an unchecked collector.
+func (r *Registry) Describe(chan<- *prometheus.Desc) {}
+
+func (r *Registry) Collect(ch chan<- prometheus.Metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.metrics {
+		m.Collect(ch)
+	}
+}
+
+// Handler returns the SDK metrics handler that emits into r.
+func (r *Registry) Handler() client.MetricsHandler {
+	return handler{registry: r}
+}
+
+// HTTPHandler returns the handler of the Prometheus endpoint serving the metrics of r,
+// and the Go runtime and process metrics.
+func (r *Registry) HTTPHandler() (http.Handler, error) {
+	gatherer := prometheus.NewRegistry()
+	for _, c := range []prometheus.Collector{r, collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})} {
+		if err := gatherer.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}), nil
+}
+
+// metric returns the metric with the given kind, name and tags, created by create if it does not exist yet.
+func (r *Registry) metric(kind string, name string, tags map[string]string, create func(prometheus.Opts) prometheus.Collector) prometheus.Collector {
+	name = sanitize(name)
+	labels := make(prometheus.Labels, len(tags))
+	for k, v := range tags {
+		labels[sanitize(k)] = v
+	}
+	key := metricKey(kind, name, labels)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, exists := r.metrics[key]
+	if !exists {
+		m = create(prometheus.Opts{Name: name, Help: "Temporal metric " + name, ConstLabels: labels})
+		r.metrics[key] = m
+	}
+	return m
+}
+
+func metricKey(kind string, name string, labels prometheus.Labels) string {
+	names := make([]string, 0, len(labels))
+	for k := range labels {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var key strings.Builder
+	key.WriteString(kind + ":" + name)
+	for _, k := range names {
+		key.WriteString("," + k + "=" + labels[k])
+	}
+	return key.String()
+}
+
+// sanitize replaces the characters Prometheus does not allow in metric and label names.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == ':' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// handler implements client.MetricsHandler. Timers are histograms in seconds, with the _seconds suffix.
+type handler struct {
+	registry *Registry
+	tags     map[string]string
+}
+
+func (h handler) WithTags(tags map[string]string) client.MetricsHandler {
+	merged := make(map[string]string, len(h.tags)+len(tags))
+	for k, v := range h.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return handler{registry: h.registry, tags: merged}
+}
+
+func (h handler) Counter(name string) client.MetricsCounter {
+	counter := h.registry.metric("counter", name, h.tags, func(opts prometheus.Opts) prometheus.Collector {
+		return prometheus.NewCounter(prometheus.CounterOpts(opts))
+	}).(prometheus.Counter)
+	return counterFunc(func(delta int64) {
+		if delta > 0 {
+			counter.Add(float64(delta))
+		}
+	})
+}
+
+func (h handler) Gauge(name string) client.MetricsGauge {
+	gauge := h.registry.metric("gauge", name, h.tags, func(opts prometheus.Opts) prometheus.Collector {
+		return prometheus.NewGauge(prometheus.GaugeOpts(opts))
+	}).(prometheus.Gauge)
+	return gaugeFunc(gauge.Set)
+}
+
+func (h handler) Timer(name string) client.MetricsTimer {
+	histogram := h.registry.metric("timer", name+"_seconds", h.tags, func(opts prometheus.Opts) prometheus.Collector {
+		return prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name: opts.Name, Help: opts.Help, ConstLabels: opts.ConstLabels, Buckets: TimerBuckets,
+		})
+	}).(prometheus.Histogram)
+	return timerFunc(func(d time.Duration) {
+		histogram.Observe(d.Seconds())
+	})
+}
+
+type counterFunc func(int64)
+
+func (f counterFunc) Inc(delta int64) { f(delta) }
+
+type gaugeFunc func(float64)
+
+func (f gaugeFunc) Update(value float64) { f(value) }
+
+type timerFunc func(time.Duration)
+
+func (f timerFunc) Record(d time.Duration) { f(d) }
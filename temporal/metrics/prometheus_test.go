@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Registry_ServesMetrics(t *testing.T) {
+	registry := NewRegistry()
+	handler := registry.Handler().WithTags(map[string]string{"workflow_type": "orchestrator-workflow"})
+
+	// The same counter with different tags, as the SDK emits them.
+	handler.Counter("temporal_request").Inc(2)
+	handler.WithTags(map[string]string{"status-code": "Unavailable"}).Counter("temporal_request").Inc(1)
+	handler.Counter("temporal_request").Inc(-1) // ignored, counters only go up
+	handler.Gauge("orchestrator_queue_depth").Update(3)
+	handler.Timer("item_processing_duration").Record(1500 * time.Millisecond)
+
+	httpHandler, err := registry.HTTPHandler()
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	httpHandler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, 200, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	require.Contains(t, string(body), `temporal_request{workflow_type="orchestrator-workflow"} 2`)
+	require.Contains(t, string(body), `temporal_request{status_code="Unavailable",workflow_type="orchestrator-workflow"} 1`)
+	require.Contains(t, string(body), `orchestrator_queue_depth{workflow_type="orchestrator-workflow"} 3`)
+	require.Contains(t, string(body), `item_processing_duration_seconds_bucket{workflow_type="orchestrator-workflow",le="2.5"} 1`)
+	require.Contains(t, string(body), `item_processing_duration_seconds_bucket{workflow_type="orchestrator-workflow",le="1"} 0`)
+	require.Contains(t, string(body), "go_goroutines")
+}
@@ -3,8 +3,23 @@ package orchestrator
 const (
 	OrchestratorWorkflowName = "orchestrator-workflow"
 	OrchestratorWorkflowID   = "orchestrator-workflow-singleton"
-	QueryName                = "orchestrator-query-list-orchestrated-items"
+	QueryName                = "orchestrator-query-list-orchestrated-items" // entire state, superseded by the queries below
+	ItemsQueryName           = "orchestrator-query-items"                   // page of filtered items, see ItemsQueryRequest
+	SummaryQueryName         = "orchestrator-query-summary"                 // item counts only
+	ItemLookupQueryName      = "orchestrator-query-item"                    // single item by ID
+	ItemQueryName            = "item-query-describe"
+	TaskQueueName            = "orchestrator-task-queue" // default task queue of the orchestrator and item workflows
 
 	ItemWorkflowAName = "ItemWorkflowA" // workflow for individual items (aka "do the work" workflow")
 	ItemWorkflowBName = "ItemWorkflowB" // workflow for individual items (aka "do the work" workflow)
+
+	ScheduledItemWorkflowName = "ScheduledItemWorkflow"    // started by the schedules, submits their item to the orchestrator
+	SubmitItemActivityName    = "orchestrator-submit-item" // signal-with-starts the orchestrator with a submit signal
+
+	// Change IDs and current versions passed to workflow.GetVersion. Bump a version (and guard the change with it)
+	// whenever the commands issued by a workflow change, and record a new history under worker/testdata.
+	ItemProtocolChangeID         = "item-protocol"
+	ItemProtocolVersion          = 2 // 1: halted items deregister with a completion report, 2: search attributes are upserted
+	OrchestratorProtocolChangeID = "orchestrator-protocol"
+	OrchestratorProtocolVersion  = 6 // 1: submitted items are launched as child workflows, 2: finished items are pruned, 3: signal payloads are validated, 4: the claim check blobs of pruned items are deleted, 5: queued items are dropped when draining, 6: the claim check blobs of pruned items are deleted before the orchestrator finishes
 )
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"net/http"
+	"time"
+)
+
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// eventQueryTimeout bounds the queries of the event stream, they block while no worker is polling.
+const eventQueryTimeout = 5 * time.Second
+
+// dashboardState is the data of the dashboard's server-sent events.
+type dashboardState struct {
+	SignalsHandled int                             `json:"signalsHandled"`
+	Config         orchestrator.OrchestratorConfig `json:"config"`
+	Items          []orchestratorclient.ItemRow    `json:"items"`
+}
+
+func dashboardHandler() http.Handler {
+	files, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		panic(err)
+	}
+	return http.FileServerFS(files)
+}
+
+// streamEvents sends the orchestrator state as server-sent events, whenever it changed since the last refresh.
+// Query failures are sent as "query-error" events and do not end the stream.
+func (s *server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, r, fmt.Errorf("streaming is not supported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(s.eventInterval)
+	defer ticker.Stop()
+	var last []byte
+	for {
+		event, data := s.dashboardEvent(r.Context())
+		if !bytes.Equal(data, last) {
+			if event != "" {
+				fmt.Fprintf(w, "event: %s\n", event)
+			}
+			fmt.Fprintf(w, "data: %s\n\n", data)
+			flusher.Flush()
+			last = data
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *server) dashboardEvent(ctx context.Context) (string, []byte) {
+	ctx, cancel := context.WithTimeout(ctx, eventQueryTimeout)
+	defer cancel()
+	state, err := s.client.QueryOrchestrator(ctx)
+	if err != nil {
+		data, _ := json.Marshal(ErrorResponse{Error: err.Error()})
+		return "query-error", data
+	}
+	data, _ := json.Marshal(dashboardState{
+		SignalsHandled: state.SignalsHandled,
+		Config:         state.Config,
+		Items:          orchestratorclient.ItemRows(state, orchestratorclient.ItemFilter{}),
+	})
+	return "", data
+}
@@ -0,0 +1,181 @@
+// Renders the orchestrator state streamed by the gateway's /events endpoint.
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+let selectedId = null;
+let lastState = null;
+
+function setBadge(el, text, kind) {
+  el.textContent = text;
+  el.className = "badge" + (kind ? " " + kind : "");
+  el.hidden = text === "";
+}
+
+function cell(row, text, className) {
+  const td = row.insertCell();
+  td.textContent = text === undefined || text === null ? "" : text;
+  if (className) {
+    td.className = className;
+  }
+  return td;
+}
+
+function formatTime(iso) {
+  return iso ? new Date(iso).toLocaleTimeString() : "";
+}
+
+function formatDuration(ms) {
+  if (ms === null || ms < 0) {
+    return "";
+  }
+  const s = Math.round(ms / 1000);
+  return s < 60 ? s + "s" : Math.floor(s / 60) + "m " + (s % 60) + "s";
+}
+
+// itemSpan returns the start and end of an item in milliseconds, the end is null while it is running.
+function itemSpan(item) {
+  const completion = item.completion;
+  const start = Date.parse((completion && completion.startedAt) || item.registeredAt);
+  const end = completion ? Date.parse(completion.finishedAt) : null;
+  return { start, end };
+}
+
+function renderSlot(state) {
+  const slot = $("slot");
+  const busy = state.items.find((item) => item.state === "in-progress");
+  slot.className = "slot" + (busy ? " busy" : "");
+  if (busy) {
+    slot.textContent = "Processing " + busy.id + " (type " + busy.type + "), registered at " + formatTime(busy.registeredAt);
+  } else if (state.config.paused) {
+    slot.textContent = "Paused, no item is started";
+  } else {
+    slot.textContent = "Free";
+  }
+}
+
+function renderQueue(state) {
+  const queued = state.items.filter((item) => item.state === "queued");
+  $("queue-count").textContent = "(" + queued.length + ")";
+  const body = $("queue");
+  body.replaceChildren();
+  queued.forEach((item, i) => {
+    const row = body.insertRow();
+    row.onclick = () => select(item.id);
+    cell(row, i + 1);
+    cell(row, item.id);
+    cell(row, item.type);
+  });
+}
+
+function renderTimeline(state) {
+  const items = state.items.filter((item) => item.state !== "queued");
+  const timeline = $("timeline");
+  timeline.replaceChildren();
+  if (items.length === 0) {
+    timeline.textContent = "No items yet";
+    return;
+  }
+  const now = Date.now();
+  const spans = items.map(itemSpan);
+  const min = Math.min(...spans.map((s) => s.start));
+  const max = Math.max(now, ...spans.map((s) => s.end || now));
+  const range = Math.max(max - min, 1);
+
+  items.forEach((item, i) => {
+    const span = spans[i];
+    const end = span.end || now;
+    const row = document.createElement("div");
+    row.className = "timeline-row";
+    row.onclick = () => select(item.id);
+
+    const label = document.createElement("div");
+    label.className = "timeline-label";
+    label.textContent = item.id;
+
+    const track = document.createElement("div");
+    track.className = "timeline-track";
+    const bar = document.createElement("div");
+    bar.className = "timeline-bar " + barClass(item);
+    bar.style.left = ((span.start - min) / range) * 100 + "%";
+    bar.style.width = ((end - span.start) / range) * 100 + "%";
+    bar.title = item.id + ": " + formatTime(new Date(span.start).toISOString()) + " – " +
+      (span.end ? formatTime(new Date(span.end).toISOString()) : "running") + " (" + formatDuration(end - span.start) + ")";
+    track.appendChild(bar);
+
+    row.append(label, track);
+    timeline.appendChild(row);
+  });
+}
+
+function barClass(item) {
+  if (item.state === "in-progress") {
+    return "in-progress";
+  }
+  if (item.completion) {
+    return item.completion.error ? "failed" : "finished";
+  }
+  return "";
+}
+
+function renderItems(state) {
+  const body = $("items");
+  body.replaceChildren();
+  state.items.forEach((item) => {
+    const row = body.insertRow();
+    row.onclick = () => select(item.id);
+    if (item.id === selectedId) {
+      row.className = "selected";
+    }
+    const span = itemSpan(item);
+    cell(row, item.id);
+    cell(row, item.type);
+    cell(row, item.state, "state-" + item.state);
+    cell(row, item.status);
+    cell(row, item.result);
+    cell(row, formatTime(item.registeredAt));
+    cell(row, item.state === "queued" ? "" : formatDuration((span.end || Date.now()) - span.start));
+  });
+}
+
+function renderDetails() {
+  const item = lastState && lastState.items.find((i) => i.id === selectedId);
+  $("details").hidden = !item;
+  if (item) {
+    $("details-id").textContent = item.id;
+    $("details-body").textContent = JSON.stringify(item, null, 2);
+  }
+}
+
+function select(id) {
+  selectedId = id;
+  render(lastState);
+}
+
+function render(state) {
+  if (!state) {
+    return;
+  }
+  lastState = state;
+  const mode = state.config.draining ? "draining" : state.config.paused ? "paused" : "";
+  setBadge($("mode"), mode, "warn");
+  $("updated").textContent = "Signals handled: " + state.signalsHandled + " · updated " + new Date().toLocaleTimeString();
+  renderSlot(state);
+  renderQueue(state);
+  renderTimeline(state);
+  renderItems(state);
+  renderDetails();
+}
+
+function connect() {
+  const events = new EventSource("/events");
+  events.onopen = () => setBadge($("connection"), "live", "ok");
+  events.onmessage = (e) => {
+    setBadge($("connection"), "live", "ok");
+    render(JSON.parse(e.data));
+  };
+  events.addEventListener("query-error", (e) => setBadge($("connection"), JSON.parse(e.data).error, "error"));
+  // EventSource reconnects by itself after errors.
+  events.onerror = () => setBadge($("connection"), "reconnecting…", "error");
+}
+
+connect();
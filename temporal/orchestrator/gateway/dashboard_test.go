@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+)
+
+func Test_Gateway_Dashboard(t *testing.T) {
+	_, h := newTestServer(t)
+
+	rec := serve(h, http.MethodGet, "/", "")
+	require.Equal(t, http.StatusFound, rec.Code)
+	require.Equal(t, "/dashboard/", rec.Header().Get("Location"))
+
+	rec = serve(h, http.MethodGet, "/dashboard/", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "<title>Orchestrator Dashboard</title>")
+
+	for _, asset := range []string{"/dashboard/app.js", "/dashboard/style.css"} {
+		require.Equal(t, http.StatusOK, serve(h, http.MethodGet, asset, "").Code, asset)
+	}
+}
+
+// readEvent reads the next server-sent event.
+func readEvent(t *testing.T, r *bufio.Reader) (string, string) {
+	var event, data string
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func Test_Gateway_Events(t *testing.T) {
+	c, h := newTestServer(t)
+	expectQuery(c, testState, nil, 1)
+	expectQuery(c, nil, serviceerror.NewNotFound("workflow not found"), 0)
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	event, data := readEvent(t, r)
+	require.Empty(t, event)
+	var state dashboardState
+	require.NoError(t, json.Unmarshal([]byte(data), &state))
+	require.Len(t, state.Items, 2)
+	require.Equal(t, "item-1", state.Items[0].ID)
+	require.Equal(t, "in-progress", state.Items[0].State)
+	require.Equal(t, "queued", state.Items[1].State)
+
+	// Query failures are reported once and keep the stream open.
+	event, data = readEvent(t, r)
+	require.Equal(t, "query-error", event)
+	require.Contains(t, data, "workflow not found")
+}
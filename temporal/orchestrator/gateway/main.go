@@ -0,0 +1,64 @@
+// gateway exposes the orchestrator operations as a REST API, for services that do not embed the Temporal SDK,
+// and serves a dashboard of the orchestrator state.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/logging"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address the HTTP server listens on")
+	eventInterval := flag.Duration("event-interval", 2*time.Second, "refresh interval of the dashboard")
+	cfg := config.New(orchestrator.TaskQueueName)
+	cfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	if err := cfg.Load(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	cfg.SetDefaultLogger()
+	shutdownTracing, err := cfg.StartTracing(context.Background(), "orchestrator-gateway")
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	c, err := cfg.Dial()
+	if err != nil {
+		logging.Fatal("Unable to create Temporal client", "error", err)
+	}
+	defer c.Close()
+
+	srv := &http.Server{
+		Addr:              *listen,
+		Handler:           newServer(orchestratorclient.New(c, cfg.TaskQueue), *eventInterval),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Unable to shut down HTTP server", "error", err)
+		}
+	}()
+
+	slog.Info("Orchestrator gateway listening", "address", *listen)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logging.Fatal("HTTP server failed", "error", err)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"my-samples-go/temporal/logging"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"my-samples-go/temporal/orchestrator/schema"
+	"net/http"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+)
+
+// SubmitRequest is the body of POST /items.
+type SubmitRequest struct {
+	Type   string            `json:"type"`             // item type, see orchestrator.ItemTypes
+	ID     string            `json:"id"`               // item ID
+	Name   string            `json:"name,omitempty"`   // generated from the type and ID if empty
+	Fields map[string]string `json:"fields,omitempty"` // type specific fields, defaults are used for missing fields
+	Push   bool              `json:"push,omitempty"`   // start the item workflow directly instead of submitting it to the orchestrator
+}
+
+// ErrorResponse is the body of all error responses.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// errBadRequest marks errors caused by an invalid request.
+var errBadRequest = errors.New("bad request")
+
+// errConflict marks requests that conflict with the orchestrator state.
+var errConflict = errors.New("conflict")
+
+// RequestIDHeader is the HTTP header with the request ID. The gateway assigns one to requests without it and
+// returns it in the response. It is logged by the gateway and the workflows started or signaled for the request.
+const RequestIDHeader = "X-Request-ID"
+
+type server struct {
+	client        *orchestratorclient.Client
+	eventInterval time.Duration // refresh interval of the dashboard's event stream
+}
+
+// newServer returns the gateway's HTTP handler.
+//
+//	POST   /items                submit an item, see SubmitRequest
+//	GET    /items                orchestrator.QueryResponse
+//	GET    /items/{id}           orchestrator.OrchestratedItem, or orchestrator.QueuedItem while it is queued
+//	DELETE /items/{id}           cancel an item
+//	POST   /orchestrator/pause   stop granting start-processing requests and launching items
+//	POST   /orchestrator/resume  undo pause and drain
+//	POST   /orchestrator/drain   stop accepting items and drop the queued ones, the orchestrator finishes once none are left
+//	GET    /events               server-sent events with the orchestrator state, used by the dashboard
+//	GET    /dashboard/           the dashboard, / redirects to it
+//
+// All requests are assigned a request ID, see RequestIDHeader.
+func newServer(client *orchestratorclient.Client, eventInterval time.Duration) http.Handler {
+	s := &server{client: client, eventInterval: eventInterval}
+	mux := http.NewServeMux()
+	mux.Handle("GET /{$}", http.RedirectHandler("/dashboard/", http.StatusFound))
+	mux.Handle("GET /dashboard/", http.StripPrefix("/dashboard/", dashboardHandler()))
+	mux.HandleFunc("GET /events", s.streamEvents)
+	mux.HandleFunc("POST /items", s.submitItem)
+	mux.HandleFunc("GET /items", s.listItems)
+	mux.HandleFunc("GET /items/{id}", s.getItem)
+	mux.HandleFunc("DELETE /items/{id}", s.cancelItem)
+	mux.HandleFunc("POST /orchestrator/pause", s.operatorSignal(orchestrator.PauseSignal))
+	mux.HandleFunc("POST /orchestrator/resume", s.operatorSignal(orchestrator.ResumeSignal))
+	mux.HandleFunc("POST /orchestrator/drain", s.operatorSignal(orchestrator.DrainSignal))
+	return withRequestID(mux)
+}
+
+// withRequestID adds the request ID of the request, or a new one, to its context and the response.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = logging.NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+	})
+}
+
+func (s *server) submitItem(w http.ResponseWriter, r *http.Request) {
+	var req SubmitRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		writeError(w, r, fmt.Errorf("%w: invalid request body: %v", errBadRequest, err))
+		return
+	}
+	if req.ID == "" {
+		writeError(w, r, fmt.Errorf("%w: item id is required", errBadRequest))
+		return
+	}
+	itemType, err := orchestrator.ItemTypes.Lookup(req.Type)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("%w: %v", errBadRequest, err))
+		return
+	}
+	fields, err := itemType.FieldValues(req.Fields)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("%w: %v", errBadRequest, err))
+		return
+	}
+	if err := s.checkNotTracked(r.Context(), req.ID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	item := itemType.NewItem(req.ID, req.Name, fields)
+
+	w.Header().Set("Location", "/items/"+req.ID)
+	if !req.Push {
+		if err := s.client.SubmitItem(r.Context(), itemType, item); err != nil {
+			writeError(w, r, fmt.Errorf("unable to submit item to orchestrator workflow: %w", err))
+			return
+		}
+		writeJSON(w, http.StatusAccepted, orchestrator.QueuedItem{ID: req.ID, WorkflowName: itemType.WorkflowName, Payload: item})
+		return
+	}
+
+	we, err := s.client.StartItem(r.Context(), itemType, item)
+	if err != nil {
+		writeError(w, r, fmt.Errorf("unable to execute workflow: %w", err))
+		return
+	}
+	// The item workflow registers itself asynchronously.
+	writeJSON(w, http.StatusAccepted, orchestrator.OrchestratedItem{
+		ID:                req.ID,
+		ItemWorkflowID:    we.GetID(),
+		ItemWorkflowRunID: we.GetRunID(),
+		WorkflowName:      itemType.WorkflowName,
+		Payload:           item,
+	})
+}
+
+// checkNotTracked fails with errConflict if an item with the ID is queued, or registered and not yet deregistered.
+// A finished item may be submitted again. The orchestrator drops duplicate items itself, so the check is skipped
+// if the state cannot be queried.
+func (s *server) checkNotTracked(ctx context.Context, itemID string) error {
+	item, queued, err := s.client.FindItem(ctx, itemID)
+	if err == nil {
+		if queued != nil || !item.Deregistered {
+			return fmt.Errorf("%w: item %s is already queued or registered", errConflict, itemID)
+		}
+		return nil
+	}
+	if !isNotFound(err) {
+		slog.WarnContext(ctx, "Unable to check for duplicate item", logging.ItemIDKey, itemID, "error", err)
+	}
+	return nil
+}
+
+func (s *server) listItems(w http.ResponseWriter, r *http.Request) {
+	state, err := s.client.QueryOrchestrator(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (s *server) getItem(w http.ResponseWriter, r *http.Request) {
+	item, queued, err := s.client.FindItem(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if queued != nil {
+		writeJSON(w, http.StatusOK, queued)
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (s *server) cancelItem(w http.ResponseWriter, r *http.Request) {
+	if err := s.client.CancelItem(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *server) operatorSignal(signalType orchestrator.SignalType) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := s.client.Signal(r.Context(), orchestrator.Signal{Type: signalType}); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("Unable to write response", "error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := httpStatus(err)
+	if status == http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "Request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+	}
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+// httpStatus maps gateway, orchestrator client and Temporal service errors to HTTP status codes.
+func httpStatus(err error) int {
+	var (
+		invalidArgument *serviceerror.InvalidArgument
+		unavailable     *serviceerror.Unavailable
+		deadline        *serviceerror.DeadlineExceeded
+	)
+	switch {
+	case errors.Is(err, errBadRequest), errors.Is(err, schema.ErrInvalid), errors.As(err, &invalidArgument):
+		return http.StatusBadRequest
+	case isNotFound(err):
+		return http.StatusNotFound
+	case errors.Is(err, errConflict), errors.Is(err, orchestratorclient.ErrItemDeregistered):
+		return http.StatusConflict
+	case errors.As(err, &unavailable), errors.As(err, &deadline), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func isNotFound(err error) bool {
+	var notFound *serviceerror.NotFound
+	return errors.Is(err, orchestratorclient.ErrItemNotFound) || errors.As(err, &notFound)
+}
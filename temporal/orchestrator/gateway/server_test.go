@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"my-samples-go/temporal/logging"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/mocks"
+)
+
+// jsonValue is a query result as returned by the Temporal client.
+type jsonValue struct {
+	v interface{}
+}
+
+func (j jsonValue) HasValue() bool { return j.v != nil }
+
+func (j jsonValue) Get(valuePtr interface{}) error {
+	data, err := json.Marshal(j.v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, valuePtr)
+}
+
+func newTestServer(t *testing.T) (*mocks.Client, http.Handler) {
+	c := mocks.NewClient(t)
+	return c, newServer(orchestratorclient.New(c, ""), 10*time.Millisecond)
+}
+
+func serve(h http.Handler, method string, target string, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+// expectQuery answers the orchestrator queries from state, or fails them with err.
+// A positive times limits how often each kind of query is answered.
+func expectQuery(c *mocks.Client, state *orchestrator.QueryResponse, err error, times int) {
+	answer := func(_ context.Context, _ string, _ string, queryType string, args ...interface{}) (converter.EncodedValue, error) {
+		if err != nil {
+			return nil, err
+		}
+		switch queryType {
+		case orchestrator.SummaryQueryName:
+			return jsonValue{orchestrator.Summarize(state.OrchestratedItems, state.QueuedItems)}, nil
+		case orchestrator.ItemsQueryName:
+			page, err := orchestrator.QueryItems(state.OrchestratedItems, state.QueuedItems, args[0].(orchestrator.ItemsQueryRequest))
+			return jsonValue{page}, err
+		case orchestrator.ItemLookupQueryName:
+			return jsonValue{orchestrator.LookupItem(state.OrchestratedItems, state.QueuedItems, args[0].(string))}, nil
+		}
+		return nil, fmt.Errorf("unknown query type %s", queryType)
+	}
+	// Queries without and with an argument.
+	for _, call := range []*mock.Call{
+		c.On("QueryWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, "", mock.Anything),
+		c.On("QueryWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, "", mock.Anything, mock.Anything),
+	} {
+		call.Return(answer, nil).Maybe()
+		if times > 0 {
+			call.Times(times)
+		}
+	}
+}
+
+var testState = &orchestrator.QueryResponse{
+	TotalItems: 1,
+	OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+		"item-1": {ID: "item-1", ItemWorkflowID: "item_item-1_x", InProgress: true},
+	},
+	QueuedItems: []orchestrator.QueuedItem{{ID: "item-2", WorkflowName: orchestrator.ItemWorkflowBName}},
+}
+
+func Test_Gateway_SubmitItem(t *testing.T) {
+	c, h := newTestServer(t)
+	expectQuery(c, testState, nil, 0)
+	c.On("SignalWithStartWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, orchestrator.SignalChannelName,
+		mock.MatchedBy(func(sig orchestrator.Signal) bool {
+			p := sig.Payload.(orchestrator.SubmitPayload)
+			return sig.Type == orchestrator.SubmitSignal && p.ID == "item-3" && p.WorkflowName == orchestrator.ItemWorkflowAName &&
+				p.Item.(*orchestrator.ItemA).ExtraFieldA == "custom"
+		}),
+		mock.Anything, orchestrator.OrchestratorWorkflowName, mock.Anything).Return(nil, nil)
+
+	rec := serve(h, http.MethodPost, "/items", `{"type": "a", "id": "item-3", "fields": {"extra-a": "custom"}}`)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	require.Equal(t, "/items/item-3", rec.Header().Get("Location"))
+	var queued orchestrator.QueuedItem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &queued))
+	require.Equal(t, "item-3", queued.ID)
+	require.Equal(t, orchestrator.ItemWorkflowAName, queued.WorkflowName)
+}
+
+func Test_Gateway_RequestID(t *testing.T) {
+	c, h := newTestServer(t)
+	expectQuery(c, testState, nil, 0)
+	// The request ID is handed to the Temporal client, which propagates it to the orchestrator.
+	c.On("SignalWithStartWorkflow", mock.MatchedBy(func(ctx context.Context) bool { return logging.RequestID(ctx) == "req-1" }),
+		orchestrator.OrchestratorWorkflowID, orchestrator.SignalChannelName, mock.Anything,
+		mock.Anything, orchestrator.OrchestratorWorkflowName, mock.Anything).Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"type": "a", "id": "item-3"}`))
+	req.Header.Set(RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	require.Equal(t, "req-1", rec.Header().Get(RequestIDHeader))
+
+	// Requests without a request ID are assigned one.
+	rec = serve(h, http.MethodGet, "/items/item-1", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NotEmpty(t, rec.Header().Get(RequestIDHeader))
+}
+
+func Test_Gateway_SubmitItem_Errors(t *testing.T) {
+	c, h := newTestServer(t)
+	expectQuery(c, testState, nil, 0)
+
+	for _, tc := range []struct {
+		body   string
+		status int
+		error  string
+	}{
+		{`{"type": "c", "id": "item-3"}`, http.StatusBadRequest, "unknown item type"},
+		{`{"type": "a"}`, http.StatusBadRequest, "item id is required"},
+		{`{"type": "a", "id": "item-3", "fields": {"extra-b": "x"}}`, http.StatusBadRequest, "unknown item field"},
+		{`{"type": "a", "id": "item-3", "unknown": 1}`, http.StatusBadRequest, "invalid request body"},
+		{`{"type": "a", "id": "item-1"}`, http.StatusConflict, "already queued or registered"},
+		{`{"type": "b", "id": "item-2"}`, http.StatusConflict, "already queued or registered"},
+	} {
+		rec := serve(h, http.MethodPost, "/items", tc.body)
+		require.Equal(t, tc.status, rec.Code, tc.body)
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Contains(t, resp.Error, tc.error, tc.body)
+	}
+}
+
+func Test_Gateway_SubmitItem_Deregistered(t *testing.T) {
+	c, h := newTestServer(t)
+	// A finished item may be submitted again.
+	expectQuery(c, &orchestrator.QueryResponse{
+		TotalItems: 1,
+		OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+			"item-1": {ID: "item-1", ItemWorkflowID: "item_item-1_x", Deregistered: true},
+		},
+	}, nil, 0)
+	c.On("SignalWithStartWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, orchestrator.SignalChannelName,
+		mock.MatchedBy(func(sig orchestrator.Signal) bool {
+			return sig.Type == orchestrator.SubmitSignal && sig.Payload.(orchestrator.SubmitPayload).ID == "item-1"
+		}),
+		mock.Anything, orchestrator.OrchestratorWorkflowName, mock.Anything).Return(nil, nil)
+
+	rec := serve(h, http.MethodPost, "/items", `{"type": "a", "id": "item-1"}`)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+}
+
+func Test_Gateway_GetItem(t *testing.T) {
+	c, h := newTestServer(t)
+	expectQuery(c, testState, nil, 0)
+
+	rec := serve(h, http.MethodGet, "/items/item-1", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var item orchestrator.OrchestratedItem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &item))
+	require.Equal(t, "item_item-1_x", item.ItemWorkflowID)
+	require.True(t, item.InProgress)
+
+	rec = serve(h, http.MethodGet, "/items/item-2", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var queued orchestrator.QueuedItem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &queued))
+	require.Equal(t, orchestrator.ItemWorkflowBName, queued.WorkflowName)
+
+	rec = serve(h, http.MethodGet, "/items/item-9", "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func Test_Gateway_ListItems_OrchestratorNotRunning(t *testing.T) {
+	c, h := newTestServer(t)
+	expectQuery(c, nil, serviceerror.NewNotFound("workflow not found"), 0)
+
+	rec := serve(h, http.MethodGet, "/items", "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
+
+func Test_Gateway_OperatorSignals(t *testing.T) {
+	c, h := newTestServer(t)
+	c.On("SignalWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, "", orchestrator.SignalChannelName,
+		orchestrator.Signal{Type: orchestrator.DrainSignal}).Return(nil)
+	c.On("SignalWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, "", orchestrator.SignalChannelName,
+		orchestrator.Signal{Type: orchestrator.PauseSignal}).Return(serviceerror.NewUnavailable("connection refused"))
+
+	require.Equal(t, http.StatusAccepted, serve(h, http.MethodPost, "/orchestrator/drain", "").Code)
+	require.Equal(t, http.StatusServiceUnavailable, serve(h, http.MethodPost, "/orchestrator/pause", "").Code)
+	require.Equal(t, http.StatusMethodNotAllowed, serve(h, http.MethodGet, "/orchestrator/drain", "").Code)
+}
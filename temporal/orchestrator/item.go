@@ -7,7 +7,7 @@ type Item interface {
 }
 
 type BasicItem struct {
-	Id     string     `json:"id"`
+	Id     string     `json:"id" jsonschema:"required"`
 	Name   string     `json:"name"`
 	Status ItemStatus `json:"status"`
 }
@@ -18,6 +18,10 @@ func (i BasicItem) ID() string {
 	return i.Id
 }
 
+func (i *BasicItem) setID(id string) {
+	i.Id = id
+}
+
 func (i BasicItem) GetStatus() string {
 	return i.Status.String()
 }
@@ -50,6 +54,29 @@ const (
 	ItemStatusCancelled  ItemStatus = "Cancelled"
 )
 
+// ItemStatuses lists all item statuses.
+var ItemStatuses = []ItemStatus{
+	ItemStatusNew, ItemStatusProcessing, ItemStatusCompleted, ItemStatusFailed, ItemStatusCancelled,
+}
+
 func (s ItemStatus) String() string {
 	return string(s)
 }
+
+// ItemPhase represents the step of the orchestration protocol an item workflow is currently in
+type ItemPhase string
+
+const (
+	ItemPhaseRegistering     ItemPhase = "Registering"     // waiting for the orchestrator to accept the registration
+	ItemPhaseWaiting         ItemPhase = "Waiting"         // registered, waiting before requesting to start processing
+	ItemPhaseRequestingStart ItemPhase = "RequestingStart" // waiting for the orchestrator to permit processing
+	ItemPhaseProcessing      ItemPhase = "Processing"      // doing the actual work
+	ItemPhaseStopping        ItemPhase = "Stopping"        // releasing the processing slot
+	ItemPhaseDeregistering   ItemPhase = "Deregistering"   // removing itself from the orchestrator
+	ItemPhaseFinished        ItemPhase = "Finished"        // workflow is about to complete, the item was processed
+	ItemPhaseFailed          ItemPhase = "Failed"          // workflow is about to complete, the item was halted, denied or failed
+)
+
+func (p ItemPhase) String() string {
+	return string(p)
+}
@@ -0,0 +1,534 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+var errUnableToProceed = errors.New("Unable to proceed")
+
+// Results of item workflows that are reported to the orchestrator on deregistration.
+const (
+	resultFinished         = "Finished Successfully"
+	resultHalted           = "Halted by orchestrator"
+	resultProcessingDenied = "Processing denied"
+)
+
+type ItemWorkflow[T Item] struct {
+	item            *T
+	managed         bool
+	protocolVersion workflow.Version
+	state           ItemQueryResponse
+	upsertedStatus  string // value of the ItemStatus search attribute
+}
+
+// itemTypeNames maps the item workflow names to the item type names. The item workflows cannot use ItemTypes
+// directly, as it refers to them.
+var itemTypeNames = make(map[string]string)
+
+func init() {
+	for _, itemType := range ItemTypes.Types() {
+		itemTypeNames[itemType.WorkflowName] = itemType.Name
+	}
+}
+
+func NewItemWorkflowA(ctx workflow.Context, item *ItemA) (*ItemWorkflow[ItemA], error) {
+	return NewItemWorkflow[ItemA](ctx, item)
+}
+
+func ItemWorkflowA(ctx workflow.Context, item ItemA) (string, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("ItemWorkflowA started")
+
+	w, err := NewItemWorkflowA(ctx, &item)
+	if err != nil {
+		logger.Error("Failed to set query handler", "error", err)
+		return "Failed to set query handler", err
+	}
+	defer w.UpsertStatus(ctx)
+	defer w.Finish(ctx)
+
+	item.Status = ItemStatusNew
+
+	if w.Managed() {
+		// Launched by the orchestrator as a child workflow, so the processing slot is already granted.
+		logger.Info("Launched by orchestrator. Starting work...")
+	} else {
+		// 1. Signal the Orchestrator Workflow to register this item,
+		// and Wait for the "go/no-go" signal from the orchestrator.
+		err = w.RegisterAndWaitForInstructions(ctx, item)
+		if errors.Is(err, errUnableToProceed) {
+			item.Status = ItemStatusCancelled
+			err = w.SendUpdate(ctx, item)
+			if err != nil {
+				item.Status = ItemStatusFailed
+				logger.Error("Failed to send update signal", "error", err)
+				return "Failed to send update signal", err
+			}
+			haltedErr := errors.New("Unable to register. Halted by orchestrator.")
+			if w.protocolVersion >= 1 {
+				err = w.Deregister(ctx, item, resultHalted, haltedErr)
+				if err != nil {
+					logger.Error("Failed to send deregister signal", "error", err)
+					return "Failed to deregister", err
+				}
+			}
+			logger.Warn("Received 'no-go' signal from orchestrator. Completing workflow without processing.")
+			return resultHalted, haltedErr
+		}
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to send register signal to orchestrator workflow", "error", err)
+			return "Failed to register", err
+		}
+
+		logger.Info("Successfully registered. Waiting 30s before requesting to start processing...")
+		w.SetPhase(ctx, ItemPhaseWaiting)
+
+		if err := workflow.Sleep(ctx, 30*time.Second); err != nil {
+			return "Failed to sleep before processing request", err
+		}
+
+		// 2. Send a StartProcessingSignal to request to start processing,
+		// and Wait for the second "go/no-go" signal for processing.
+		err = w.StartProcessingAndWaitForInstructions(ctx, item)
+		if errors.Is(err, errUnableToProceed) {
+			item.Status = ItemStatusCancelled
+			err = w.SendUpdate(ctx, item)
+			if err != nil {
+				item.Status = ItemStatusFailed
+				logger.Error("Failed to send update signal", "error", err)
+				return "Failed to send update signal", err
+			}
+			logger.Warn("Received 'no-go' signal from orchestrator. Completing workflow without processing.")
+			return resultProcessingDenied, errors.New("Unable to process. Processing denied by orchestrator.")
+		}
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to start processing", "error", err)
+			return "Failed to start processing", err
+		}
+
+		logger.Info("Request to process was approved. Starting work...")
+	}
+
+	item.Status = ItemStatusProcessing
+	w.SetPhase(ctx, ItemPhaseProcessing)
+
+	// 3. Signal the Orchestrator Workflow with the update (status update).
+	err = w.SendUpdate(ctx, item)
+	if err != nil {
+		item.Status = ItemStatusFailed
+		logger.Error("Failed to send update signal", "error", err)
+		return "Failed to send update signal", err
+	}
+
+	// Simulate doing work for 30 seconds
+	logger.Info("Starting processing...")
+	if err := workflow.Sleep(ctx, 30*time.Second); err != nil {
+		item.Status = ItemStatusFailed
+		return "Failed to sleep", err
+	}
+
+	logger.Info("Item processing complete. Stopping processing.")
+	item.Status = ItemStatusCompleted
+	w.SetPhase(ctx, ItemPhaseStopping)
+
+	// 4. Signal the Orchestrator Workflow with the update (status update).
+	err = w.SendUpdate(ctx, item)
+	if err != nil {
+		item.Status = ItemStatusFailed
+		logger.Error("Failed to send update signal", "error", err)
+		return "Failed to send update signal", err
+	}
+
+	// A managed item releases its slot and is deregistered by the orchestrator when it completes.
+	if !w.Managed() {
+		// 5. Signal the Orchestrator Workflow to stop processing.
+		err = w.StopProcessingAndWaitForInstructions(ctx, item)
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to send stop-processing signal", "error", err)
+			return "Failed to stop processing", err
+		}
+
+		// 6. Signal the Orchestrator Workflow to deregister this item.
+		w.SetPhase(ctx, ItemPhaseDeregistering)
+		err = w.Deregister(ctx, item, resultFinished, nil)
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to send deregister signal", "error", err)
+			return "Failed to deregister", err
+		}
+	}
+
+	return resultFinished, nil
+}
+
+func NewItemWorkflowB(ctx workflow.Context, item *ItemB) (*ItemWorkflow[ItemB], error) {
+	return NewItemWorkflow[ItemB](ctx, item)
+}
+
+func ItemWorkflowB(ctx workflow.Context, item ItemB) (string, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("ItemWorkflowB started")
+
+	w, err := NewItemWorkflowB(ctx, &item)
+	if err != nil {
+		logger.Error("Failed to set query handler", "error", err)
+		return "Failed to set query handler", err
+	}
+	defer w.UpsertStatus(ctx)
+	defer w.Finish(ctx)
+
+	item.Status = ItemStatusNew
+
+	if w.Managed() {
+		// Launched by the orchestrator as a child workflow, so the processing slot is already granted.
+		logger.Info("Launched by orchestrator. Starting work...")
+	} else {
+		// 1. Signal the Orchestrator Workflow to register this item,
+		// and Wait for the "go/no-go" signal from the orchestrator.
+		err = w.RegisterAndWaitForInstructions(ctx, item)
+		if errors.Is(err, errUnableToProceed) {
+			item.Status = ItemStatusCancelled
+			err = w.SendUpdate(ctx, item)
+			if err != nil {
+				item.Status = ItemStatusFailed
+				logger.Error("Failed to send update signal", "error", err)
+				return "Failed to send update signal", err
+			}
+			haltedErr := errors.New("Unable to register. Halted by orchestrator.")
+			if w.protocolVersion >= 1 {
+				err = w.Deregister(ctx, item, resultHalted, haltedErr)
+				if err != nil {
+					logger.Error("Failed to send deregister signal", "error", err)
+					return "Failed to deregister", err
+				}
+			}
+			logger.Warn("Received 'no-go' signal from orchestrator. Completing workflow without processing.")
+			return resultHalted, haltedErr
+		}
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to send register signal to orchestrator workflow", "error", err)
+			return "Failed to register", err
+		}
+
+		logger.Info("Successfully registered. Waiting 30s before requesting to start processing...")
+		w.SetPhase(ctx, ItemPhaseWaiting)
+
+		if err := workflow.Sleep(ctx, 30*time.Second); err != nil {
+			return "Failed to sleep before processing request", err
+		}
+
+		// 2. Send a StartProcessingSignal to request to start processing,
+		// and Wait for the second "go/no-go" signal for processing.
+		err = w.StartProcessingAndWaitForInstructions(ctx, item)
+		if errors.Is(err, errUnableToProceed) {
+			item.Status = ItemStatusCancelled
+			err = w.SendUpdate(ctx, item)
+			if err != nil {
+				item.Status = ItemStatusFailed
+				logger.Error("Failed to send update signal", "error", err)
+				return "Failed to send update signal", err
+			}
+			logger.Warn("Received 'no-go' signal from orchestrator. Completing workflow without processing.")
+			return resultProcessingDenied, errors.New("Unable to process. Processing denied by orchestrator.")
+		}
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to start processing", "error", err)
+			return "Failed to start processing", err
+		}
+
+		logger.Info("Request to process was approved. Starting work...")
+	}
+
+	item.Status = ItemStatusProcessing
+	w.SetPhase(ctx, ItemPhaseProcessing)
+
+	// 3. Signal the Orchestrator Workflow with the update (status update).
+	err = w.SendUpdate(ctx, item)
+	if err != nil {
+		item.Status = ItemStatusFailed
+		logger.Error("Failed to send update signal", "error", err)
+		return "Failed to send update signal", err
+	}
+
+	// Simulate doing work for 30 seconds
+	logger.Info("Starting processing...")
+	if err := workflow.Sleep(ctx, 30*time.Second); err != nil {
+		item.Status = ItemStatusFailed
+		return "Failed to sleep", err
+	}
+
+	logger.Info("Item processing complete. Stopping processing.")
+	item.Status = ItemStatusCompleted
+	w.SetPhase(ctx, ItemPhaseStopping)
+
+	// 4. Signal the Orchestrator Workflow with the update (status update).
+	err = w.SendUpdate(ctx, item)
+	if err != nil {
+		item.Status = ItemStatusFailed
+		logger.Error("Failed to send update signal", "error", err)
+		return "Failed to send update signal", err
+	}
+
+	// A managed item releases its slot and is deregistered by the orchestrator when it completes.
+	if !w.Managed() {
+		// 5. Signal the Orchestrator Workflow to stop processing.
+		err = w.StopProcessingAndWaitForInstructions(ctx, item)
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to send stop-processing signal", "error", err)
+			return "Failed to stop processing", err
+		}
+
+		// 6. Signal the Orchestrator Workflow to deregister this item.
+		w.SetPhase(ctx, ItemPhaseDeregistering)
+		err = w.Deregister(ctx, item, resultFinished, nil)
+		if err != nil {
+			item.Status = ItemStatusFailed
+			logger.Error("Failed to send deregister signal", "error", err)
+			return "Failed to deregister", err
+		}
+	}
+
+	return resultFinished, nil
+}
+
+// ItemWorkflow is the workflow that processes a single item.
+// It tracks its own progress and exposes it through the ItemQueryName query handler.
+func NewItemWorkflow[T Item](ctx workflow.Context, item *T) (*ItemWorkflow[T], error) {
+	expandItemIDTemplate(ctx, item)
+	info := workflow.GetInfo(ctx)
+	now := workflow.Now(ctx)
+	parent := info.ParentWorkflowExecution
+	w := &ItemWorkflow[T]{
+		item:            item,
+		managed:         parent != nil && parent.ID == OrchestratorWorkflowID,
+		protocolVersion: workflow.GetVersion(ctx, ItemProtocolChangeID, workflow.DefaultVersion, ItemProtocolVersion),
+		state: ItemQueryResponse{
+			ID:                (*item).ID(),
+			ItemWorkflowID:    info.WorkflowExecution.ID,
+			ItemWorkflowRunID: info.WorkflowExecution.RunID,
+			Phase:             ItemPhaseRegistering,
+			RetryCount:        int(info.Attempt) - 1,
+			StartedAt:         now,
+			PhaseChangedAt:    now,
+		},
+	}
+
+	err := workflow.SetQueryHandler(ctx, ItemQueryName, func() (ItemQueryResponse, error) {
+		return w.Describe(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decision := DecisionPending
+	if w.managed {
+		decision = DecisionLaunched
+	}
+	w.upsertedStatus = ItemStatusNew.String()
+	w.upsertSearchAttributes(ctx,
+		ItemIDSearchAttribute.ValueSet((*item).ID()),
+		ItemTypeSearchAttribute.ValueSet(itemTypeNames[info.WorkflowType.Name]),
+		ItemStatusSearchAttribute.ValueSet(w.upsertedStatus),
+		OrchestratorDecisionSearchAttribute.ValueSet(decision.String()),
+	)
+	return w, nil
+}
+
+// Describe returns a snapshot of the item workflow's current state.
+func (w *ItemWorkflow[T]) Describe() ItemQueryResponse {
+	resp := w.state
+	resp.Status = (*w.item).GetStatus()
+	return resp
+}
+
+// Managed reports whether the item workflow was launched by the orchestrator as a child workflow
+// (pull model) instead of being started externally and registering itself (push model).
+func (w *ItemWorkflow[T]) Managed() bool {
+	return w.managed
+}
+
+// SetPhase records the protocol step the item workflow has moved to, and the duration of the processing phase once it ends.
+func (w *ItemWorkflow[T]) SetPhase(ctx workflow.Context, phase ItemPhase) {
+	now := workflow.Now(ctx)
+	if w.state.Phase == ItemPhaseProcessing && phase != ItemPhaseProcessing {
+		workflow.GetMetricsHandler(ctx).Timer(ItemProcessingTimeMetric).Record(now.Sub(w.state.PhaseChangedAt))
+	}
+	w.state.Phase = phase
+	w.state.PhaseChangedAt = now
+}
+
+// Finish moves the item workflow to its terminal phase, Finished if the item was completed and Failed otherwise.
+// It is deferred like UpsertStatus, so the phase is final on every return.
+func (w *ItemWorkflow[T]) Finish(ctx workflow.Context) {
+	if (*w.item).GetStatus() == ItemStatusCompleted.String() {
+		w.SetPhase(ctx, ItemPhaseFinished)
+	} else {
+		w.SetPhase(ctx, ItemPhaseFailed)
+	}
+}
+
+// recordInstruction records an instruction of the orchestrator, decision is the decision it stands for.
+func (w *ItemWorkflow[T]) recordInstruction(ctx workflow.Context, instruction ItemInstructionSignal, decision OrchestratorDecision) {
+	receivedAt := workflow.Now(ctx)
+	w.state.LastInstruction = &instruction
+	w.state.LastInstructionAt = &receivedAt
+	w.upsertSearchAttributes(ctx, OrchestratorDecisionSearchAttribute.ValueSet(decision.String()))
+}
+
+// UpsertStatus updates the ItemStatus search attribute if the item status changed since the last update.
+func (w *ItemWorkflow[T]) UpsertStatus(ctx workflow.Context) {
+	status := (*w.item).GetStatus()
+	if status == w.upsertedStatus {
+		return
+	}
+	w.upsertedStatus = status
+	w.upsertSearchAttributes(ctx, ItemStatusSearchAttribute.ValueSet(status))
+}
+
+// upsertSearchAttributes updates the search attributes of item workflows started with protocol version 2 or later.
+// Failing to update them does not fail the item.
+func (w *ItemWorkflow[T]) upsertSearchAttributes(ctx workflow.Context, updates ...temporal.SearchAttributeUpdate) {
+	if w.protocolVersion < 2 {
+		return
+	}
+	if err := workflow.UpsertTypedSearchAttributes(ctx, updates...); err != nil {
+		workflow.GetLogger(ctx).Warn("Failed to upsert search attributes", "error", err)
+	}
+}
+
+func (w *ItemWorkflow[T]) RegisterAndWaitForInstructions(ctx workflow.Context, item T) error {
+	// Signal the Orchestrator Workflow to register this item.
+	info := workflow.GetInfo(ctx)
+	registerPayload := RegisterPayload{
+		ID:                item.ID(),
+		ItemWorkflowID:    info.WorkflowExecution.ID,
+		ItemWorkflowRunID: info.WorkflowExecution.RunID,
+		WorkflowName:      info.WorkflowType.Name,
+		Item:              item,
+	}
+	registerSignal := Signal{
+		Type:    RegisterSignal,
+		Payload: registerPayload,
+	}
+
+	// Use SignalWorkflow from within the workflow to signal the orchestrator
+	err := workflow.SignalExternalWorkflow(ctx, OrchestratorWorkflowID, "", SignalChannelName, registerSignal).Get(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to send register signal to orchestrator workflow: %w", err)
+	}
+
+	// Wait for the "go/no-go" signal from the orchestrator.
+	signalCh := workflow.GetSignalChannel(ctx, ItemSignalChannelName)
+	var processSignal ItemInstructionSignal
+	signalCh.Receive(ctx, &processSignal) // This will block until the signal is received
+	decision := DecisionRegistered
+	if !processSignal.Proceed {
+		decision = DecisionHalted
+	}
+	w.recordInstruction(ctx, processSignal, decision)
+
+	// Decide whether to proceed based on the signal.
+	if !processSignal.Proceed {
+		return errors.Join(errUnableToProceed, fmt.Errorf("Received 'no-go' signal from orchestrator. Reason: %s", processSignal.Reason))
+	}
+
+	return nil
+}
+
+func (w *ItemWorkflow[T]) StartProcessingAndWaitForInstructions(ctx workflow.Context, item T) error {
+	w.SetPhase(ctx, ItemPhaseRequestingStart)
+
+	// Signal the Orchestrator Workflow to request permission to start processing this item.
+	startProcessingPayload := StartProcessingPayload{ID: item.ID()}
+	startProcessingSignal := Signal{
+		Type:    StartProcessingSignal,
+		Payload: startProcessingPayload,
+	}
+	err := workflow.SignalExternalWorkflow(ctx, OrchestratorWorkflowID, "", SignalChannelName, startProcessingSignal).Get(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to send start-processing signal to orchestrator workflow: %w", err)
+	}
+
+	// Wait for the "go/no-go" signal for processing.
+	signalCh := workflow.GetSignalChannel(ctx, ItemSignalChannelName)
+	var processSignal ItemInstructionSignal
+	signalCh.Receive(ctx, &processSignal) // Block until the signal is received
+	decision := DecisionApproved
+	if !processSignal.Proceed {
+		decision = DecisionDenied
+	}
+	w.recordInstruction(ctx, processSignal, decision)
+	if !processSignal.Proceed {
+		deniedErr := fmt.Errorf("Request to process was denied by orchestrator. Reason: %s", processSignal.Reason)
+		// Also deregister since we are not proceeding.
+		err = w.Deregister(ctx, item, resultProcessingDenied, deniedErr)
+		if err != nil {
+			return fmt.Errorf("Failed to send deregister signal after processing denial: %w", err)
+		}
+		return errors.Join(errUnableToProceed, deniedErr)
+	}
+
+	return nil
+}
+
+func (w *ItemWorkflow[T]) StopProcessingAndWaitForInstructions(ctx workflow.Context, item T) error {
+	// Signal the Orchestrator Workflow to request permission to stop processing this item.
+	stopProcessingPayload := StopProcessingPayload{ID: item.ID()}
+	stopProcessingSignal := Signal{
+		Type:    StopProcessingSignal,
+		Payload: stopProcessingPayload,
+	}
+	err := workflow.SignalExternalWorkflow(ctx, OrchestratorWorkflowID, "", SignalChannelName, stopProcessingSignal).Get(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to send stop-processing signal to orchestrator workflow: %w", err)
+	}
+
+	return nil
+}
+
+func (w *ItemWorkflow[T]) Deregister(ctx workflow.Context, item T, result string, resultErr error) error {
+	// Signal the Orchestrator Workflow to deregister this item, reporting how it ended.
+	deregisterPayload := DeregisterPayload{
+		ID:     item.ID(),
+		Report: NewCompletionReport(result, resultErr, w.state.StartedAt, workflow.Now(ctx)),
+	}
+	deregisterSignal := Signal{
+		Type:    DeregisterSignal,
+		Payload: deregisterPayload,
+	}
+	err := workflow.SignalExternalWorkflow(ctx, OrchestratorWorkflowID, "", SignalChannelName, deregisterSignal).Get(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to send deregister signal to orchestrator workflow: %w", err)
+	}
+
+	return nil
+}
+
+func (w *ItemWorkflow[T]) SendUpdate(ctx workflow.Context, item T) error {
+	w.UpsertStatus(ctx)
+	updatePayload := UpdatePayload{
+		ID:   item.ID(),
+		Item: item,
+	}
+	updateSignal := Signal{
+		Type:    UpdateSignal,
+		Payload: updatePayload,
+	}
+	err := workflow.SignalExternalWorkflow(ctx, OrchestratorWorkflowID, "", SignalChannelName, updateSignal).Get(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to send update signal to orchestrator workflow: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package orchestrator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func Test_ItemWorkflowA_Query(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	// The orchestrator is not running in this test, accept every signal sent to it.
+	env.OnSignalExternalWorkflow(mock.Anything, OrchestratorWorkflowID, "", SignalChannelName, mock.Anything).Return(nil)
+
+	queryItem := func() ItemQueryResponse {
+		val, err := env.QueryWorkflow(ItemQueryName)
+		require.NoError(t, err)
+		var resp ItemQueryResponse
+		require.NoError(t, val.Get(&resp))
+		return resp
+	}
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryItem()
+		require.Equal(t, "item-1", resp.ID)
+		require.Equal(t, ItemPhaseRegistering, resp.Phase)
+		require.Equal(t, ItemStatusNew.String(), resp.Status)
+		require.Nil(t, resp.LastInstruction)
+
+		env.SignalWorkflow(ItemSignalChannelName, ItemInstructionSignal{ID: "item-1", Proceed: true, Reason: "Registration accepted."})
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryItem()
+		require.Equal(t, ItemPhaseWaiting, resp.Phase)
+		require.NotNil(t, resp.LastInstruction)
+		require.Equal(t, "Registration accepted.", resp.LastInstruction.Reason)
+	}, 10*time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryItem()
+		require.Equal(t, ItemPhaseRequestingStart, resp.Phase)
+
+		env.SignalWorkflow(ItemSignalChannelName, ItemInstructionSignal{ID: "item-1", Proceed: true, Reason: "Start processing permitted."})
+	}, 40*time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryItem()
+		require.Equal(t, ItemPhaseProcessing, resp.Phase)
+		require.Equal(t, ItemStatusProcessing.String(), resp.Status)
+		require.Equal(t, "Start processing permitted.", resp.LastInstruction.Reason)
+	}, 50*time.Second)
+
+	env.ExecuteWorkflow(ItemWorkflowA, ItemA{BasicItem: BasicItem{Id: "item-1", Name: "Item-A-item-1"}})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result string
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, "Finished Successfully", result)
+
+	resp := queryItem()
+	require.Equal(t, ItemPhaseFinished, resp.Phase)
+	require.Equal(t, ItemStatusCompleted.String(), resp.Status)
+	require.Equal(t, 0, resp.RetryCount)
+}
+
+func Test_ItemWorkflowB_SearchAttributes(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.OnSignalExternalWorkflow(mock.Anything, OrchestratorWorkflowID, "", SignalChannelName, mock.Anything).Return(nil)
+
+	attributes := make(map[string]string)
+	var decisions []string
+	env.OnUpsertTypedSearchAttributes(mock.Anything).Run(func(args mock.Arguments) {
+		upserted := args.Get(0).(temporal.SearchAttributes)
+		for _, key := range SearchAttributes {
+			if value, ok := upserted.GetKeyword(key.(temporal.SearchAttributeKeyKeyword)); ok {
+				attributes[key.GetName()] = value
+			}
+		}
+		if decision, ok := upserted.GetKeyword(OrchestratorDecisionSearchAttribute); ok {
+			decisions = append(decisions, decision)
+		}
+	}).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(ItemSignalChannelName, ItemInstructionSignal{ID: "item-2", Proceed: true, Reason: "Registration accepted."})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(ItemSignalChannelName, ItemInstructionSignal{ID: "item-2", Proceed: false, Reason: "Another item is processing."})
+	}, 40*time.Second)
+
+	env.ExecuteWorkflow(ItemWorkflowB, ItemB{BasicItem: BasicItem{Id: "item-2", Name: "Item-B-item-2"}})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Error(t, env.GetWorkflowError())
+	require.Equal(t, map[string]string{
+		"ItemID":               "item-2",
+		"ItemType":             "b",
+		"ItemStatus":           ItemStatusCancelled.String(),
+		"OrchestratorDecision": DecisionDenied.String(),
+	}, attributes)
+	require.Equal(t, []string{DecisionPending.String(), DecisionRegistered.String(), DecisionDenied.String()}, decisions)
+
+	// The denied workflow ended, so the query no longer reports it as waiting for the orchestrator.
+	val, err := env.QueryWorkflow(ItemQueryName)
+	require.NoError(t, err)
+	var resp ItemQueryResponse
+	require.NoError(t, val.Get(&resp))
+	require.Equal(t, ItemPhaseFailed, resp.Phase)
+}
@@ -0,0 +1,37 @@
+package orchestrator
+
+import (
+	"my-samples-go/temporal/logging"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// LogFields returns the attributes attached to all log lines of the orchestrator and item workflows, see
+// logging.NewInterceptor: the item ID and type of item workflows, and the run ID of the orchestrator
+// workflow, or of the orchestrator that launched the item. Templated item IDs are expanded as the item workflow does.
+func LogFields(ctx workflow.Context, args []interface{}) []interface{} {
+	info := workflow.GetInfo(ctx)
+	if info.WorkflowType.Name == OrchestratorWorkflowName {
+		return []interface{}{logging.OrchestratorRunIDKey, info.WorkflowExecution.RunID}
+	}
+	typeName, ok := itemTypeNames[info.WorkflowType.Name]
+	if !ok {
+		return nil
+	}
+	fields := []interface{}{logging.ItemTypeKey, typeName}
+	if len(args) > 0 {
+		if item, ok := args[0].(Item); ok {
+			id := item.ID()
+			if IsItemIDTemplate(id) {
+				if expanded, err := expandItemID(ctx, id); err == nil {
+					id = expanded
+				}
+			}
+			fields = append(fields, logging.ItemIDKey, id)
+		}
+	}
+	if parent := info.ParentWorkflowExecution; parent != nil && parent.ID == OrchestratorWorkflowID {
+		fields = append(fields, logging.OrchestratorRunIDKey, parent.RunID)
+	}
+	return fields
+}
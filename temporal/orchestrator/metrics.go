@@ -0,0 +1,42 @@
+package orchestrator
+
+import (
+	"go.temporal.io/sdk/workflow"
+)
+
+// Custom metrics emitted by the workflows through workflow.GetMetricsHandler, which skips them during replay.
+// The SDK tags them with the namespace, task queue and workflow type.
+const (
+	SignalsHandledMetric     = "orchestrator_signals_handled"   // counter, tagged with the signal type
+	RequestsGrantedMetric    = "orchestrator_requests_granted"  // counter of accepted registrations and start-processing requests, tagged with the signal type
+	RequestsDeniedMetric     = "orchestrator_requests_denied"   // counter of denied registrations and start-processing requests, tagged with the signal type
+	QueueDepthMetric         = "orchestrator_queue_depth"       // gauge of the submitted items waiting to be launched
+	ItemsRegisteredMetric    = "orchestrator_items_registered"  // gauge of the registered, not yet deregistered items
+	ItemsInProgressMetric    = "orchestrator_items_in_progress" // gauge of the items holding the processing slot
+	ItemProcessingTimeMetric = "item_processing_duration"       // timer of the processing phase of the item workflows
+	SignalTypeTag            = "signal_type"
+)
+
+// RecordDecision counts a request of an item workflow the orchestrator granted or denied.
+func RecordDecision(ctx workflow.Context, signalType SignalType, granted bool) {
+	name := RequestsDeniedMetric
+	if granted {
+		name = RequestsGrantedMetric
+	}
+	workflow.GetMetricsHandler(ctx).WithTags(map[string]string{SignalTypeTag: string(signalType)}).Counter(name).Inc(1)
+}
+
+// RecordStateGauges updates the gauges of the orchestrator state.
+func RecordStateGauges(ctx workflow.Context, stateManager OrchestratorStateManager) {
+	registered := stateManager.RegisteredItems()
+	inProgress := 0
+	for _, item := range registered {
+		if item.InProgress {
+			inProgress++
+		}
+	}
+	handler := workflow.GetMetricsHandler(ctx)
+	handler.Gauge(QueueDepthMetric).Update(float64(len(stateManager.QueuedItems())))
+	handler.Gauge(ItemsRegisteredMetric).Update(float64(len(registered)))
+	handler.Gauge(ItemsInProgressMetric).Update(float64(inProgress))
+}
@@ -2,11 +2,33 @@ package orchestrator
 
 import (
 	"errors"
+	"sort"
+	"time"
 )
 
 type OrchestratorState struct {
 	SignalsHandled    int
 	OrchestratedItems map[string]OrchestratedItem
+	QueuedItems       []QueuedItem // items submitted to the orchestrator, waiting to be launched as child workflows
+	Config            OrchestratorConfig
+	PrunedItems       []PrunedItem // pruned items whose claim check blobs are not deleted yet
+}
+
+// PrunedItem is an item removed from the state by PruneItems. The claim check blobs its item workflow refers to
+// are deleted once no history the orchestrator still needs refers to them.
+type PrunedItem struct {
+	ID                string    `json:"id"`
+	ItemWorkflowID    string    `json:"itemWorkflowId"`
+	ItemWorkflowRunID string    `json:"itemWorkflowRunId"`
+	FinishedAt        time.Time `json:"finishedAt"`
+}
+
+// OrchestratorConfig holds the operator controlled settings of the orchestrator.
+type OrchestratorConfig struct {
+	Paused      bool          `json:"paused"`                // no item may start processing
+	Draining    bool          `json:"draining"`              // no new items are accepted, the orchestrator finishes once none are left
+	IdleTimeout time.Duration `json:"idleTimeout,omitempty"` // overrides the default idle timeout if set
+	Retention   time.Duration `json:"retention,omitempty"`   // deregistered items are pruned this long after they finished, never if 0
 }
 
 func (o *OrchestratorState) IncrementSignalsHandled() {
@@ -24,24 +46,73 @@ func (o *OrchestratorState) GetSignalsHandled() int {
 
 type OrchestratorStateManager interface {
 	GetState() *OrchestratorState
-	RegisterItem(itemID string, itemWorkflowID string, itemWorkflowRunID string, item interface{}) error
+	RegisterItem(itemID string, itemWorkflowID string, itemWorkflowRunID string, workflowName string, registeredAt time.Time, item interface{}) error
 	StartProcessing(itemID string) (*OrchestratedItem, error)
 	StopProcessing(itemID string) (*OrchestratedItem, error)
 	UpdateItem(itemID string, item interface{}) error
-	Deregister(itemID string) error
+	Deregister(itemID string, report *CompletionReport, deregisteredAt time.Time) error
+	PruneItems(finishedBefore time.Time) []OrchestratedItem
 	AllItems() map[string]OrchestratedItem
 	RegisteredItems() map[string]OrchestratedItem
+	EnqueueItem(itemID string, workflowName string, item interface{}) error
+	DequeueItem() *QueuedItem
+	QueuedItems() []QueuedItem
+	RemoveQueuedItem(itemID string) error
+	HasCapacity() bool
+	Config() OrchestratorConfig
+	SetConfig(config OrchestratorConfig)
 }
 
 type CreateOrchestratorStateManagerFunc[O OrchestratorStateManager] func(state *OrchestratorState) O
 
 type OrchestratedItem struct {
-	ID                string      `json:"id"`
-	ItemWorkflowID    string      `json:"itemWorkflowId"`
-	ItemWorkflowRunID string      `json:"itemWorkflowRunId"`
-	InProgress        bool        `json:"inProgress"`
-	Deregistered      bool        `json:"deregistered"`
-	Payload           interface{} `json:"payload"`
+	ID                string            `json:"id"`
+	ItemWorkflowID    string            `json:"itemWorkflowId"`
+	ItemWorkflowRunID string            `json:"itemWorkflowRunId"`
+	WorkflowName      string            `json:"workflowName,omitempty"` // item workflow type, empty for items registered by older item workflows
+	RegisteredAt      time.Time         `json:"registeredAt"`
+	InProgress        bool              `json:"inProgress"`
+	Deregistered      bool              `json:"deregistered"`
+	Payload           interface{}       `json:"payload"`
+	Completion        *CompletionReport `json:"completion,omitempty"`
+}
+
+// CompletionReport describes how an item workflow ended. It is sent with the deregister signal.
+type CompletionReport struct {
+	Result     string        `json:"result"`          // item workflow result, e.g. "Finished Successfully"
+	Error      string        `json:"error,omitempty"` // error message if the item did not finish successfully
+	StartedAt  time.Time     `json:"startedAt"`
+	FinishedAt time.Time     `json:"finishedAt"`
+	Duration   time.Duration `json:"duration"`
+}
+
+func NewCompletionReport(result string, err error, startedAt time.Time, finishedAt time.Time) *CompletionReport {
+	report := &CompletionReport{
+		Result:     result,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		Duration:   finishedAt.Sub(startedAt),
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	return report
+}
+
+// FinishedAt returns when a deregistered item finished. Items deregistered without a completion report count as
+// finished when they were registered.
+func (item OrchestratedItem) FinishedAt() time.Time {
+	if item.Completion != nil {
+		return item.Completion.FinishedAt
+	}
+	return item.RegisteredAt
+}
+
+// QueuedItem is an item submitted to the orchestrator that has not been launched yet.
+type QueuedItem struct {
+	ID           string      `json:"id"`
+	WorkflowName string      `json:"workflowName"`
+	Payload      interface{} `json:"payload"`
 }
 
 type ItemOrchestratorStateManager struct {
@@ -51,8 +122,11 @@ type ItemOrchestratorStateManager struct {
 var _ OrchestratorStateManager = (*ItemOrchestratorStateManager)(nil)
 
 var (
-	itemNotRegisteredError = errors.New("item not registered")
-	anotherItemInProgress  = errors.New("another item already in progress")
+	itemNotRegisteredError     = errors.New("item not registered")
+	anotherItemInProgress      = errors.New("another item already in progress")
+	itemAlreadyRegisteredError = errors.New("item already registered or queued")
+	orchestratorPausedError    = errors.New("orchestrator is paused")
+	orchestratorDrainingError  = errors.New("orchestrator is draining")
 )
 
 func NewItemOrchestratorStateManager(state *OrchestratorState) OrchestratorStateManager {
@@ -75,7 +149,7 @@ func (o *ItemOrchestratorStateManager) GetState() *OrchestratorState {
 	return o.state
 }
 
-func (o *ItemOrchestratorStateManager) RegisterItem(itemID string, itemWorkflowID string, itemWorkflowRunID string, item interface{}) error {
+func (o *ItemOrchestratorStateManager) RegisterItem(itemID string, itemWorkflowID string, itemWorkflowRunID string, workflowName string, registeredAt time.Time, item interface{}) error {
 	if o == nil {
 		return errors.New("orchestrator state manager is nil")
 	}
@@ -83,8 +157,15 @@ func (o *ItemOrchestratorStateManager) RegisterItem(itemID string, itemWorkflowI
 		ID:                itemID,
 		ItemWorkflowID:    itemWorkflowID,
 		ItemWorkflowRunID: itemWorkflowRunID,
+		WorkflowName:      workflowName,
+		RegisteredAt:      registeredAt,
 		Payload:           item,
 	}
+	if o.state.Config.Draining {
+		newItem.Deregistered = true
+		o.state.OrchestratedItems[itemID] = newItem
+		return orchestratorDrainingError
+	}
 	if itemInProgress := o.getItemInProgress(); itemInProgress != nil && itemInProgress.ID != itemID {
 		newItem.Deregistered = true
 		o.state.OrchestratedItems[itemID] = newItem
@@ -99,6 +180,9 @@ func (o *ItemOrchestratorStateManager) StartProcessing(itemID string) (*Orchestr
 		return nil, errors.New("orchestrator state manager is nil")
 	}
 	if item, exists := o.state.OrchestratedItems[itemID]; exists {
+		if o.state.Config.Paused {
+			return &item, orchestratorPausedError
+		}
 		if itemInProgress := o.getItemInProgress(); itemInProgress != nil && itemInProgress.ID != itemID {
 			return &item, anotherItemInProgress
 		}
@@ -136,12 +220,26 @@ func (o *ItemOrchestratorStateManager) UpdateItem(itemID string, item interface{
 	return nil
 }
 
-func (o *ItemOrchestratorStateManager) Deregister(itemID string) error {
+// Deregister marks the item as deregistered with its completion report. Reports sent without times, e.g. by an
+// operator cancelling the item, count from the registration of the item to deregisteredAt.
+func (o *ItemOrchestratorStateManager) Deregister(itemID string, report *CompletionReport, deregisteredAt time.Time) error {
 	if o == nil {
 		return errors.New("orchestrator state manager is nil")
 	}
 	if item, exists := o.state.OrchestratedItems[itemID]; exists {
 		item.Deregistered = true
+		if report != nil && report.FinishedAt.IsZero() {
+			stamped := *report
+			if stamped.StartedAt.IsZero() {
+				stamped.StartedAt = item.RegisteredAt
+			}
+			stamped.FinishedAt = deregisteredAt
+			stamped.Duration = stamped.FinishedAt.Sub(stamped.StartedAt)
+			report = &stamped
+		}
+		if report != nil {
+			item.Completion = report
+		}
 		o.state.OrchestratedItems[itemID] = item
 	} else {
 		return itemNotRegisteredError
@@ -149,6 +247,26 @@ func (o *ItemOrchestratorStateManager) Deregister(itemID string) error {
 	return nil
 }
 
+// PruneItems removes the deregistered items that finished before finishedBefore from the state and returns them.
+func (o *ItemOrchestratorStateManager) PruneItems(finishedBefore time.Time) []OrchestratedItem {
+	if o == nil {
+		return nil
+	}
+	var pruned []OrchestratedItem
+	for id, item := range o.state.OrchestratedItems {
+		if !item.Deregistered {
+			continue
+		}
+		if item.FinishedAt().Before(finishedBefore) {
+			pruned = append(pruned, item)
+			delete(o.state.OrchestratedItems, id)
+		}
+	}
+	// Map iteration order is random, return the items in a deterministic order for the workflow.
+	sort.Slice(pruned, func(i, j int) bool { return pruned[i].ID < pruned[j].ID })
+	return pruned
+}
+
 func (o *ItemOrchestratorStateManager) AllItems() map[string]OrchestratedItem {
 	if o == nil {
 		return nil
@@ -169,6 +287,84 @@ func (o *ItemOrchestratorStateManager) RegisteredItems() map[string]Orchestrated
 	return registered
 }
 
+// EnqueueItem adds a submitted item to the end of the queue. It is rejected if an item
+// with the same ID is already queued or registered.
+func (o *ItemOrchestratorStateManager) EnqueueItem(itemID string, workflowName string, item interface{}) error {
+	if o == nil {
+		return errors.New("orchestrator state manager is nil")
+	}
+	if o.state.Config.Draining {
+		return orchestratorDrainingError
+	}
+	if existing, exists := o.state.OrchestratedItems[itemID]; exists && !existing.Deregistered {
+		return itemAlreadyRegisteredError
+	}
+	for _, queued := range o.state.QueuedItems {
+		if queued.ID == itemID {
+			return itemAlreadyRegisteredError
+		}
+	}
+	o.state.QueuedItems = append(o.state.QueuedItems, QueuedItem{
+		ID:           itemID,
+		WorkflowName: workflowName,
+		Payload:      item,
+	})
+	return nil
+}
+
+// DequeueItem removes and returns the oldest queued item, or nil if the queue is empty.
+func (o *ItemOrchestratorStateManager) DequeueItem() *QueuedItem {
+	if o == nil || len(o.state.QueuedItems) == 0 {
+		return nil
+	}
+	next := o.state.QueuedItems[0]
+	o.state.QueuedItems = o.state.QueuedItems[1:]
+	return &next
+}
+
+func (o *ItemOrchestratorStateManager) QueuedItems() []QueuedItem {
+	if o == nil {
+		return nil
+	}
+	return o.state.QueuedItems
+}
+
+// RemoveQueuedItem removes a submitted item from the queue before it is launched.
+func (o *ItemOrchestratorStateManager) RemoveQueuedItem(itemID string) error {
+	if o == nil {
+		return errors.New("orchestrator state manager is nil")
+	}
+	for i, queued := range o.state.QueuedItems {
+		if queued.ID == itemID {
+			o.state.QueuedItems = append(o.state.QueuedItems[:i], o.state.QueuedItems[i+1:]...)
+			return nil
+		}
+	}
+	return itemNotRegisteredError
+}
+
+// HasCapacity reports whether another item may start processing.
+func (o *ItemOrchestratorStateManager) HasCapacity() bool {
+	if o == nil {
+		return false
+	}
+	return !o.state.Config.Paused && o.getItemInProgress() == nil
+}
+
+func (o *ItemOrchestratorStateManager) Config() OrchestratorConfig {
+	if o == nil {
+		return OrchestratorConfig{}
+	}
+	return o.state.Config
+}
+
+func (o *ItemOrchestratorStateManager) SetConfig(config OrchestratorConfig) {
+	if o == nil {
+		return
+	}
+	o.state.Config = config
+}
+
 func (o *ItemOrchestratorStateManager) getItemInProgress() *OrchestratedItem {
 	if o == nil {
 		return nil
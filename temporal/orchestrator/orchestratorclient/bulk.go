@@ -0,0 +1,340 @@
+package orchestratorclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"my-samples-go/temporal/orchestrator"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BulkItem is an item definition read from a bulk submission file.
+type BulkItem struct {
+	Line   int // line in the input file, for error messages and the report
+	Type   string
+	ID     string
+	Name   string
+	Fields map[string]string // type specific fields, defaults are used for missing or empty fields
+}
+
+// Bulk result statuses
+const (
+	BulkCompleted = "completed" // the item workflow finished, see BulkResult.Outcome
+	BulkFailed    = "failed"    // the item could not be started or its workflow failed
+	BulkSkipped   = "skipped"   // the item was a duplicate
+)
+
+// BulkResult is the report entry of a bulk submitted item.
+type BulkResult struct {
+	Line       int    `json:"line"`
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	WorkflowID string `json:"workflowId"`
+	RunID      string `json:"runId"`
+	Status     string `json:"status"`
+	Outcome    string `json:"outcome"` // item workflow result, e.g. "Finished Successfully"
+	Error      string `json:"error,omitempty"`
+}
+
+// BulkOptions controls a bulk submission.
+type BulkOptions struct {
+	// Parallelism is the number of items handled at the same time. If zero, it is DefaultBulkParallelism with
+	// Submit, and 1 without: the orchestrator halts item workflows started while another item is processing.
+	Parallelism  int
+	Submit       bool          // submit the items to the orchestrator instead of starting the item workflows directly
+	PollInterval time.Duration // with Submit, how often the orchestrator is queried for the outcome, 2 seconds if zero
+	Timeout      time.Duration // how long to wait for the outcome of each item, no limit if zero
+}
+
+// DefaultBulkParallelism is the number of items submitted to the orchestrator at the same time by default.
+const DefaultBulkParallelism = 4
+
+// Bulk file formats
+const (
+	BulkFormatCSV   = "csv"
+	BulkFormatJSONL = "jsonl"
+)
+
+// BulkFormat derives the bulk file format from a file name.
+func BulkFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return BulkFormatCSV, nil
+	case ".jsonl", ".ndjson":
+		return BulkFormatJSONL, nil
+	default:
+		return "", fmt.Errorf("unknown bulk file format of %s, use a .csv or .jsonl file", path)
+	}
+}
+
+// ReadBulkItems reads item definitions. CSV files need a header with the columns type, id and optionally name,
+// all other columns are type specific fields. JSONL files hold one object per line with the same keys.
+func ReadBulkItems(r io.Reader, format string) ([]BulkItem, error) {
+	switch format {
+	case BulkFormatCSV:
+		return readBulkCSV(r)
+	case BulkFormatJSONL:
+		return readBulkJSONL(r)
+	default:
+		return nil, fmt.Errorf("unknown bulk file format %q", format)
+	}
+}
+
+func readBulkCSV(r io.Reader) ([]BulkItem, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	var items []BulkItem
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		values := make(map[string]string, len(header))
+		for i, column := range header {
+			values[column] = record[i]
+		}
+		items = append(items, newBulkItem(line, values))
+	}
+}
+
+func readBulkJSONL(r io.Reader) ([]BulkItem, error) {
+	var items []BulkItem
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var values map[string]string
+		if err := json.Unmarshal([]byte(text), &values); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, newBulkItem(line, values))
+	}
+	return items, scanner.Err()
+}
+
+func newBulkItem(line int, values map[string]string) BulkItem {
+	item := BulkItem{Line: line, Fields: make(map[string]string)}
+	for key, value := range values {
+		value = strings.TrimSpace(value)
+		switch key {
+		case "type":
+			item.Type = value
+		case "id":
+			item.ID = value
+		case "name":
+			item.Name = value
+		default:
+			if value != "" {
+				item.Fields[key] = value
+			}
+		}
+	}
+	return item
+}
+
+// SubmitBulk starts or submits the items, at most opts.Parallelism at a time, and waits for their outcome.
+// Items with an ID seen before in items are skipped. The results are in the order of items.
+func (c *Client) SubmitBulk(ctx context.Context, items []BulkItem, opts BulkOptions) []BulkResult {
+	parallelism := opts.Parallelism
+	if parallelism == 0 && opts.Submit {
+		parallelism = DefaultBulkParallelism
+	}
+	return runBulk(ctx, items, parallelism, func(ctx context.Context, item BulkItem) BulkResult {
+		if opts.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+		}
+		return c.submitBulkItem(ctx, item, opts)
+	})
+}
+
+// runBulk calls process for every unique item with bounded parallelism.
+func runBulk(ctx context.Context, items []BulkItem, parallelism int, process func(ctx context.Context, item BulkItem) BulkResult) []BulkResult {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	results := make([]BulkResult, len(items))
+	seen := make(map[string]int, len(items))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, item := range items {
+		if firstLine, duplicate := seen[item.ID]; duplicate {
+			results[i] = BulkResult{Line: item.Line, ID: item.ID, Type: item.Type, Status: BulkSkipped,
+				Error: "duplicate of line " + strconv.Itoa(firstLine)}
+			continue
+		}
+		seen[item.ID] = item.Line
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, item BulkItem) {
+			defer func() { <-sem; wg.Done() }()
+			results[i] = process(ctx, item)
+		}(i, item)
+	}
+	wg.Wait()
+	return results
+}
+
+func (c *Client) submitBulkItem(ctx context.Context, bulkItem BulkItem, opts BulkOptions) BulkResult {
+	result := BulkResult{Line: bulkItem.Line, ID: bulkItem.ID, Type: bulkItem.Type, Status: BulkFailed}
+	fail := func(err error) BulkResult {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("no outcome within %s, the item may still finish: %w", opts.Timeout, err)
+		}
+		result.Error = err.Error()
+		return result
+	}
+	if bulkItem.ID == "" {
+		return fail(errors.New("item id is required"))
+	}
+	itemType, err := orchestrator.ItemTypes.Lookup(bulkItem.Type)
+	if err != nil {
+		return fail(err)
+	}
+	result.Type = itemType.Name
+	fields, err := itemType.FieldValues(bulkItem.Fields)
+	if err != nil {
+		return fail(err)
+	}
+	item := itemType.NewItem(bulkItem.ID, bulkItem.Name, fields)
+
+	if opts.Submit {
+		// A draining orchestrator drops submissions. If it cannot be queried, it is started by the submission.
+		if summary, err := c.QuerySummary(ctx); err == nil && summary.Config.Draining {
+			return fail(fmt.Errorf("%w, resume it to submit items", ErrDraining))
+		}
+		// A finished item may be submitted again, its previous entry is replaced once the new one is launched.
+		previousWorkflowID := ""
+		if previous, queued, err := c.FindItem(ctx, bulkItem.ID); err == nil {
+			if queued != nil || !previous.Deregistered {
+				return fail(fmt.Errorf("item %s is already queued or registered", bulkItem.ID))
+			}
+			previousWorkflowID = previous.ItemWorkflowID
+		}
+		if err := c.SubmitItem(ctx, itemType, item); err != nil {
+			return fail(fmt.Errorf("unable to submit item to orchestrator workflow: %w", err))
+		}
+		orchestrated, err := c.waitForDeregistration(ctx, bulkItem.ID, previousWorkflowID, opts.PollInterval)
+		if orchestrated != nil {
+			result.WorkflowID, result.RunID = orchestrated.ItemWorkflowID, orchestrated.ItemWorkflowRunID
+		}
+		if err != nil {
+			return fail(err)
+		}
+		if orchestrated.Completion != nil {
+			result.Outcome = orchestrated.Completion.Result
+			if orchestrated.Completion.Error != "" {
+				return fail(errors.New(orchestrated.Completion.Error))
+			}
+		}
+		result.Status = BulkCompleted
+		return result
+	}
+
+	we, err := c.StartItem(ctx, itemType, item)
+	if err != nil {
+		return fail(fmt.Errorf("unable to execute workflow: %w", err))
+	}
+	result.WorkflowID, result.RunID = we.GetID(), we.GetRunID()
+	if err := we.Get(ctx, &result.Outcome); err != nil {
+		return fail(fmt.Errorf("workflow failed: %w", err))
+	}
+	result.Status = BulkCompleted
+	return result
+}
+
+// submissionPolls is the number of polls within which a submitted item has to show up in the orchestrator's
+// state. The orchestrator drops invalid and duplicate submissions without a trace.
+const submissionPolls = 30
+
+// waitForDeregistration polls the orchestrator until a submitted item was launched and has finished.
+// Entries of the item with previousWorkflowID are from an earlier submission and ignored. An item that
+// disappears from the state after it was seen, because it was cancelled or pruned, fails the wait, as does
+// an item that is not queued or registered within submissionPolls polls.
+func (c *Client) waitForDeregistration(ctx context.Context, itemID string, previousWorkflowID string, pollInterval time.Duration) (*orchestrator.OrchestratedItem, error) {
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	seen := false
+	for polls := 1; ; polls++ {
+		item, queued, err := c.FindItem(ctx, itemID)
+		if err != nil && !errors.Is(err, ErrItemNotFound) {
+			return nil, err
+		}
+		if item != nil && item.ItemWorkflowID == previousWorkflowID {
+			item = nil
+		}
+		if item != nil && item.Deregistered {
+			return item, nil
+		}
+		if item != nil || queued != nil {
+			seen = true
+		} else if seen {
+			return nil, fmt.Errorf("%w: %s was cancelled or pruned before it finished", ErrItemNotFound, itemID)
+		} else if polls >= submissionPolls {
+			return nil, fmt.Errorf("%w: %s was not queued after %d polls, the orchestrator dropped the submission", ErrItemNotFound, itemID, polls)
+		}
+		select {
+		case <-ctx.Done():
+			return item, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+var bulkReportColumns = []string{"line", "id", "type", "workflow_id", "run_id", "status", "outcome", "error"}
+
+// WriteBulkReport writes the results as CSV (BulkFormatCSV) or one JSON object per line (BulkFormatJSONL).
+func WriteBulkReport(w io.Writer, format string, results []BulkResult) error {
+	switch format {
+	case BulkFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(bulkReportColumns); err != nil {
+			return err
+		}
+		for _, r := range results {
+			if err := cw.Write([]string{strconv.Itoa(r.Line), r.ID, r.Type, r.WorkflowID, r.RunID, r.Status, r.Outcome, r.Error}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case BulkFormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, r := range results {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown bulk report format %q", format)
+	}
+}
@@ -0,0 +1,158 @@
+package orchestratorclient
+
+import (
+	"bytes"
+	"context"
+	"my-samples-go/temporal/orchestrator"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/mocks"
+)
+
+func Test_ReadBulkItems(t *testing.T) {
+	csvInput := "type,id,name,extra-a\n" +
+		"a, item-1, First,custom\n" +
+		"b,item-2,,\n"
+	items, err := ReadBulkItems(strings.NewReader(csvInput), BulkFormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, []BulkItem{
+		{Line: 2, Type: "a", ID: "item-1", Name: "First", Fields: map[string]string{"extra-a": "custom"}},
+		{Line: 3, Type: "b", ID: "item-2", Fields: map[string]string{}},
+	}, items)
+
+	jsonlInput := `{"type": "a", "id": "item-1", "extra-a": "custom"}
+
+{"type": "b", "id": "item-2", "name": "Second"}
+`
+	items, err = ReadBulkItems(strings.NewReader(jsonlInput), BulkFormatJSONL)
+	require.NoError(t, err)
+	require.Equal(t, []BulkItem{
+		{Line: 1, Type: "a", ID: "item-1", Fields: map[string]string{"extra-a": "custom"}},
+		{Line: 3, Type: "b", ID: "item-2", Name: "Second", Fields: map[string]string{}},
+	}, items)
+
+	_, err = ReadBulkItems(strings.NewReader(`{"type": "a", "id": 1}`), BulkFormatJSONL)
+	require.ErrorContains(t, err, "line 1")
+
+	format, err := BulkFormat("items.JSONL")
+	require.NoError(t, err)
+	require.Equal(t, BulkFormatJSONL, format)
+	_, err = BulkFormat("items.txt")
+	require.Error(t, err)
+}
+
+func Test_RunBulk_DeduplicatesAndBoundsParallelism(t *testing.T) {
+	var items []BulkItem
+	for i, id := range []string{"item-1", "item-2", "item-1", "item-3", "item-4", "item-5"} {
+		items = append(items, BulkItem{Line: i + 2, ID: id, Type: "a"})
+	}
+
+	var running, maxRunning, calls int32
+	results := runBulk(context.Background(), items, 2, func(ctx context.Context, item BulkItem) BulkResult {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return BulkResult{Line: item.Line, ID: item.ID, Status: BulkCompleted, Outcome: "Finished Successfully"}
+	})
+
+	require.EqualValues(t, 5, calls)
+	require.EqualValues(t, 2, maxRunning)
+	require.Len(t, results, len(items))
+	for i, r := range results {
+		require.Equal(t, items[i].ID, r.ID)
+	}
+	require.Equal(t, BulkSkipped, results[2].Status)
+	require.Equal(t, "duplicate of line 2", results[2].Error)
+	require.Equal(t, BulkCompleted, results[3].Status)
+}
+
+func Test_SubmitBulk_InvalidItems(t *testing.T) {
+	// Invalid items fail before the Temporal client is used.
+	c := New(nil, "")
+	results := c.SubmitBulk(context.Background(), []BulkItem{
+		{Line: 2, Type: "c", ID: "item-1"},
+		{Line: 3, Type: "a"},
+		{Line: 4, Type: "a", ID: "item-2", Fields: map[string]string{"extra-b": "x"}},
+	}, BulkOptions{Parallelism: 2})
+
+	for _, r := range results {
+		require.Equal(t, BulkFailed, r.Status, r.Line)
+	}
+	require.Contains(t, results[0].Error, "unknown item type")
+	require.Equal(t, "item id is required", results[1].Error)
+	require.Contains(t, results[2].Error, "unknown item field")
+}
+
+func Test_WaitForDeregistration(t *testing.T) {
+	c := mocks.NewClient(t)
+	oc := New(c, "")
+	queued := orchestrator.QueryResponse{QueuedItems: []orchestrator.QueuedItem{{ID: "item-1"}}}
+	running := orchestrator.QueryResponse{OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+		"item-1": {ID: "item-1", ItemWorkflowID: "item_item-1_x", InProgress: true},
+	}}
+	finished := orchestrator.QueryResponse{OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+		"item-1": {ID: "item-1", ItemWorkflowID: "item_item-1_x", Deregistered: true},
+	}}
+
+	// The submission is not handled yet, then the item is queued, launched and finishes.
+	expectState(t, c, orchestrator.QueryResponse{}, queued, running, finished)
+	item, err := oc.waitForDeregistration(context.Background(), "item-1", "", time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, "item_item-1_x", item.ItemWorkflowID)
+
+	// An item pruned between two polls is not waited for forever.
+	expectState(t, c, running, orchestrator.QueryResponse{})
+	_, err = oc.waitForDeregistration(context.Background(), "item-1", "", time.Millisecond)
+	require.ErrorIs(t, err, ErrItemNotFound)
+
+	// A dropped submission is not waited for forever.
+	states := make([]orchestrator.QueryResponse, submissionPolls)
+	expectState(t, c, states...)
+	_, err = oc.waitForDeregistration(context.Background(), "item-1", "", time.Millisecond)
+	require.ErrorIs(t, err, ErrItemNotFound)
+	require.Contains(t, err.Error(), "dropped the submission")
+}
+
+func Test_SubmitBulk_Draining(t *testing.T) {
+	c := mocks.NewClient(t)
+	value := mocks.NewEncodedValue(t)
+	value.On("Get", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*orchestrator.SummaryResponse) = orchestrator.SummaryResponse{Config: orchestrator.OrchestratorConfig{Draining: true}}
+	}).Return(nil)
+	c.On("QueryWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, "", orchestrator.SummaryQueryName).Return(value, nil)
+
+	// Nothing is submitted to a draining orchestrator.
+	results := New(c, "").SubmitBulk(context.Background(), []BulkItem{{Line: 2, Type: "a", ID: "item-1"}}, BulkOptions{Submit: true})
+	require.Equal(t, BulkFailed, results[0].Status)
+	require.Contains(t, results[0].Error, "draining")
+}
+
+func Test_WriteBulkReport(t *testing.T) {
+	results := []BulkResult{
+		{Line: 2, ID: "item-1", Type: "a", WorkflowID: "item_item-1_x", RunID: "run", Status: BulkCompleted, Outcome: "Finished Successfully"},
+		{Line: 3, ID: "item-1", Type: "a", Status: BulkSkipped, Error: "duplicate of line 2"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBulkReport(&buf, BulkFormatCSV, results))
+	require.Equal(t, "line,id,type,workflow_id,run_id,status,outcome,error\n"+
+		"2,item-1,a,item_item-1_x,run,completed,Finished Successfully,\n"+
+		"3,item-1,a,,,skipped,,duplicate of line 2\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteBulkReport(&buf, BulkFormatJSONL, results))
+	require.Equal(t, `{"line":2,"id":"item-1","type":"a","workflowId":"item_item-1_x","runId":"run","status":"completed","outcome":"Finished Successfully"}`+"\n"+
+		`{"line":3,"id":"item-1","type":"a","workflowId":"","runId":"","status":"skipped","outcome":"","error":"duplicate of line 2"}`+"\n", buf.String())
+}
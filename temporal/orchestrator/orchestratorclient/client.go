@@ -0,0 +1,232 @@
+// Package orchestratorclient implements the client side operations of the orchestrator sample,
+// shared by the starter, the query client, orchestratorctl and the gateway.
+package orchestratorclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/schema"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+)
+
+const DefaultTaskQueue = orchestrator.TaskQueueName
+
+var (
+	ErrItemNotFound     = errors.New("item not found")
+	ErrItemDeregistered = errors.New("item already deregistered")
+	ErrDraining         = errors.New("orchestrator is draining")
+)
+
+// Client performs orchestrator operations through a Temporal client.
+type Client struct {
+	Temporal  client.Client
+	TaskQueue string
+	// DataConverter decodes the payloads the SDK returns undecoded, e.g. schedule memos. It must match the
+	// data converter of Temporal.
+	DataConverter converter.DataConverter
+}
+
+func New(c client.Client, taskQueue string) *Client {
+	if taskQueue == "" {
+		taskQueue = DefaultTaskQueue
+	}
+	return &Client{Temporal: c, TaskQueue: taskQueue, DataConverter: converter.GetDefaultDataConverter()}
+}
+
+// SignalWithStart sends a signal to the orchestrator, starting it first if it is not running.
+// Signals with a payload not matching its schema fail with an error wrapping schema.ErrInvalid.
+func (c *Client) SignalWithStart(ctx context.Context, sig orchestrator.Signal) error {
+	if err := schema.ValidateSignal(sig); err != nil {
+		return err
+	}
+	options := client.StartWorkflowOptions{
+		ID:        orchestrator.OrchestratorWorkflowID,
+		TaskQueue: c.TaskQueue,
+	}
+	_, err := c.Temporal.SignalWithStartWorkflow(ctx,
+		orchestrator.OrchestratorWorkflowID,
+		orchestrator.SignalChannelName,
+		sig,
+		options,
+		orchestrator.OrchestratorWorkflowName,
+		orchestrator.OrchestratorState{})
+	return err
+}
+
+// Signal sends a signal to the running orchestrator.
+func (c *Client) Signal(ctx context.Context, sig orchestrator.Signal) error {
+	if err := schema.ValidateSignal(sig); err != nil {
+		return err
+	}
+	return c.Temporal.SignalWorkflow(ctx, orchestrator.OrchestratorWorkflowID, "", orchestrator.SignalChannelName, sig)
+}
+
+// EnsureOrchestratorRunning sends a benign ping signal, which starts the orchestrator if it is not running.
+func (c *Client) EnsureOrchestratorRunning(ctx context.Context) error {
+	return c.SignalWithStart(ctx, orchestrator.Signal{Type: orchestrator.PingSignal})
+}
+
+// StartItem starts an item workflow that registers itself with the orchestrator (push model).
+func (c *Client) StartItem(ctx context.Context, itemType orchestrator.ItemType, item orchestrator.Item) (client.WorkflowRun, error) {
+	if err := schema.ValidateItem(itemType.WorkflowName, item); err != nil {
+		return nil, err
+	}
+	if err := c.EnsureOrchestratorRunning(ctx); err != nil {
+		return nil, fmt.Errorf("unable to signal/start orchestrator workflow: %w", err)
+	}
+	options := client.StartWorkflowOptions{
+		ID:        "item_" + item.ID() + "_" + uuid.New().String(),
+		TaskQueue: c.TaskQueue,
+	}
+	return c.Temporal.ExecuteWorkflow(ctx, options, itemType.WorkflowName, item)
+}
+
+// SubmitItem hands an item to the orchestrator, which launches it as a child workflow (pull model).
+func (c *Client) SubmitItem(ctx context.Context, itemType orchestrator.ItemType, item orchestrator.Item) error {
+	return c.SignalWithStart(ctx, orchestrator.Signal{
+		Type: orchestrator.SubmitSignal,
+		Payload: orchestrator.SubmitPayload{
+			ID:           item.ID(),
+			WorkflowName: itemType.WorkflowName,
+			Item:         item,
+		},
+	})
+}
+
+// QueryOrchestrator returns the orchestrator's current state with all items.
+func (c *Client) QueryOrchestrator(ctx context.Context) (*orchestrator.QueryResponse, error) {
+	return c.QueryState(ctx, orchestrator.ItemsQueryRequest{})
+}
+
+// QueryState returns the orchestrator's counters, configuration and pruned items with the items selected by request.
+// The items are fetched page by page, request.PageToken is ignored.
+func (c *Client) QueryState(ctx context.Context, request orchestrator.ItemsQueryRequest) (*orchestrator.QueryResponse, error) {
+	summary, err := c.QuerySummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	items, queued, err := c.QueryItems(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	state := &orchestrator.QueryResponse{
+		TotalItems:        summary.TotalItems,
+		OrchestratedItems: make(map[string]orchestrator.OrchestratedItem, len(items)),
+		QueuedItems:       queued,
+		SignalsHandled:    summary.SignalsHandled,
+		Config:            summary.Config,
+		PrunedItems:       summary.PrunedItems,
+	}
+	for _, item := range items {
+		state.OrchestratedItems[item.ID] = item
+	}
+	return state, nil
+}
+
+// QueryItems returns the items and queued items selected by request, following the pages from request.PageToken
+// to the last one. An item that moved between two pages is only returned once.
+func (c *Client) QueryItems(ctx context.Context, request orchestrator.ItemsQueryRequest) ([]orchestrator.OrchestratedItem, []orchestrator.QueuedItem, error) {
+	var items []orchestrator.OrchestratedItem
+	var queued []orchestrator.QueuedItem
+	seen := make(map[string]bool)
+	for {
+		var page orchestrator.ItemsQueryResponse
+		if err := c.query(ctx, &page, orchestrator.ItemsQueryName, request); err != nil {
+			return nil, nil, err
+		}
+		for _, item := range page.Items {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				items = append(items, item)
+			}
+		}
+		for _, item := range page.QueuedItems {
+			if !seen[item.ID] {
+				seen[item.ID] = true
+				queued = append(queued, item)
+			}
+		}
+		if page.NextPageToken == "" {
+			return items, queued, nil
+		}
+		request.PageToken = page.NextPageToken
+	}
+}
+
+// QuerySummary returns the orchestrator's item counts, counters, configuration and pruned items without any items.
+func (c *Client) QuerySummary(ctx context.Context) (*orchestrator.SummaryResponse, error) {
+	var summary orchestrator.SummaryResponse
+	if err := c.query(ctx, &summary, orchestrator.SummaryQueryName); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// query runs an orchestrator query and decodes its result into valuePtr.
+func (c *Client) query(ctx context.Context, valuePtr interface{}, queryType string, args ...interface{}) error {
+	resp, err := c.Temporal.QueryWorkflow(ctx, orchestrator.OrchestratorWorkflowID, "", queryType, args...)
+	if err != nil {
+		return fmt.Errorf("unable to query workflow: %w", err)
+	}
+	if err := resp.Get(valuePtr); err != nil {
+		return fmt.Errorf("unable to decode query result: %w", err)
+	}
+	return nil
+}
+
+// DescribeItem queries an item workflow directly; this also works for completed item workflows.
+func (c *Client) DescribeItem(ctx context.Context, itemWorkflowID string) (*orchestrator.ItemQueryResponse, error) {
+	resp, err := c.Temporal.QueryWorkflow(ctx, itemWorkflowID, "", orchestrator.ItemQueryName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query item workflow: %w", err)
+	}
+	var itemState orchestrator.ItemQueryResponse
+	if err := resp.Get(&itemState); err != nil {
+		return nil, fmt.Errorf("unable to decode item query result: %w", err)
+	}
+	return &itemState, nil
+}
+
+// FindItem looks an item up in the orchestrator's state. Exactly one of the returned items is set.
+func (c *Client) FindItem(ctx context.Context, itemID string) (*orchestrator.OrchestratedItem, *orchestrator.QueuedItem, error) {
+	var resp orchestrator.ItemLookupResponse
+	if err := c.query(ctx, &resp, orchestrator.ItemLookupQueryName, itemID); err != nil {
+		return nil, nil, err
+	}
+	if resp.Item == nil && resp.QueuedItem == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrItemNotFound, itemID)
+	}
+	return resp.Item, resp.QueuedItem, nil
+}
+
+// CancelItem removes a queued item from the orchestrator, or cancels the item workflow of a registered item
+// and deregisters it so its processing slot is released.
+func (c *Client) CancelItem(ctx context.Context, itemID string) error {
+	item, queued, err := c.FindItem(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if queued != nil {
+		return c.Signal(ctx, orchestrator.Signal{Type: orchestrator.CancelSignal, Payload: orchestrator.CancelPayload{ID: itemID}})
+	}
+	if item.Deregistered {
+		return fmt.Errorf("%w: %s", ErrItemDeregistered, itemID)
+	}
+	if err := c.Temporal.CancelWorkflow(ctx, item.ItemWorkflowID, item.ItemWorkflowRunID); err != nil {
+		return fmt.Errorf("unable to cancel item workflow: %w", err)
+	}
+	return c.DeregisterItem(ctx, itemID, &orchestrator.CompletionReport{Result: "Cancelled", Error: "Cancelled by operator"})
+}
+
+// DeregisterItem deregisters an item on its behalf, e.g. after its workflow was terminated.
+func (c *Client) DeregisterItem(ctx context.Context, itemID string, report *orchestrator.CompletionReport) error {
+	return c.Signal(ctx, orchestrator.Signal{
+		Type:    orchestrator.DeregisterSignal,
+		Payload: orchestrator.DeregisterPayload{ID: itemID, Report: report},
+	})
+}
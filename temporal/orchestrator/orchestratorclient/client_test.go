@@ -0,0 +1,42 @@
+package orchestratorclient
+
+import (
+	"context"
+	"my-samples-go/temporal/orchestrator"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/mocks"
+)
+
+func expectItemsPage(t *testing.T, c *mocks.Client, pageToken string, page orchestrator.ItemsQueryResponse) {
+	value := mocks.NewEncodedValue(t)
+	value.On("Get", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*orchestrator.ItemsQueryResponse) = page
+	}).Return(nil).Once()
+	request := orchestrator.ItemsQueryRequest{Statuses: []string{"New"}, PageToken: pageToken}
+	c.On("QueryWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, "", orchestrator.ItemsQueryName, request).Return(value, nil).Once()
+}
+
+func Test_QueryItems_FollowsPages(t *testing.T) {
+	c := mocks.NewClient(t)
+	expectItemsPage(t, c, "", orchestrator.ItemsQueryResponse{
+		Items:         []orchestrator.OrchestratedItem{{ID: "item-1"}, {ID: "item-2"}},
+		NextPageToken: "page-2",
+	})
+	// item-2 moved while paging and is returned again.
+	expectItemsPage(t, c, "page-2", orchestrator.ItemsQueryResponse{
+		Items:         []orchestrator.OrchestratedItem{{ID: "item-2"}},
+		QueuedItems:   []orchestrator.QueuedItem{{ID: "item-3"}},
+		NextPageToken: "page-3",
+	})
+	expectItemsPage(t, c, "page-3", orchestrator.ItemsQueryResponse{
+		QueuedItems: []orchestrator.QueuedItem{{ID: "item-4"}},
+	})
+
+	items, queued, err := New(c, "").QueryItems(context.Background(), orchestrator.ItemsQueryRequest{Statuses: []string{"New"}})
+	require.NoError(t, err)
+	require.Equal(t, []orchestrator.OrchestratedItem{{ID: "item-1"}, {ID: "item-2"}}, items)
+	require.Equal(t, []orchestrator.QueuedItem{{ID: "item-3"}, {ID: "item-4"}}, queued)
+}
@@ -0,0 +1,204 @@
+package orchestratorclient
+
+import (
+	"flag"
+	"fmt"
+	"my-samples-go/temporal/orchestrator"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Item states as shown by ItemRow.State
+const (
+	ItemStateQueued       = "queued"
+	ItemStateRegistered   = "registered"
+	ItemStateInProgress   = "in-progress"
+	ItemStateDeregistered = "deregistered"
+)
+
+// ItemRow is the flattened view of a queued or orchestrated item used for listing.
+type ItemRow struct {
+	ID                string                         `json:"id"`
+	Type              string                         `json:"type"`
+	State             string                         `json:"state"`
+	Status            string                         `json:"status"`
+	Result            string                         `json:"result,omitempty"`
+	RegisteredAt      *time.Time                     `json:"registeredAt,omitempty"` // nil for queued items
+	ItemWorkflowID    string                         `json:"itemWorkflowId,omitempty"`
+	ItemWorkflowRunID string                         `json:"itemWorkflowRunId,omitempty"`
+	Completion        *orchestrator.CompletionReport `json:"completion,omitempty"`
+	Payload           interface{}                    `json:"payload"`
+}
+
+// Sort orders
+const (
+	SortByRegistration = "registered"
+	SortByID           = "id"
+)
+
+// ItemFilter selects and orders the items of a QueryResponse. The zero value selects all items.
+type ItemFilter struct {
+	Statuses     []string // item statuses, matched case-insensitively
+	Types        []string // item type names, see orchestrator.ItemTypes
+	IDPrefix     string
+	InProgress   *bool
+	Deregistered *bool
+	SortBy       string // SortByRegistration (default) or SortByID
+}
+
+// RegisterFlags registers the filter and sort flags on fs.
+func (f *ItemFilter) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("status", "only show items with this status, comma separated (e.g. New,Processing)", func(v string) error {
+		f.Statuses = append(f.Statuses, splitList(v)...)
+		return nil
+	})
+	fs.Func("type", "only show items of this type, comma separated ("+strings.Join(orchestrator.ItemTypes.Names(), ", ")+")", func(v string) error {
+		for _, name := range splitList(v) {
+			itemType, err := orchestrator.ItemTypes.Lookup(name)
+			if err != nil {
+				return err
+			}
+			f.Types = append(f.Types, itemType.Name)
+		}
+		return nil
+	})
+	fs.StringVar(&f.IDPrefix, "id-prefix", "", "only show items whose ID starts with this prefix")
+	fs.Var(&optionalBool{&f.InProgress}, "in-progress", "only show items that are (true) or are not (false) in progress")
+	fs.Var(&optionalBool{&f.Deregistered}, "deregistered", "only show items that are (true) or are not (false) deregistered")
+	fs.StringVar(&f.SortBy, "sort", SortByRegistration, "sort order: "+SortByRegistration+" (registration time) or "+SortByID)
+}
+
+// Validate checks the sort order.
+func (f *ItemFilter) Validate() error {
+	switch f.SortBy {
+	case "", SortByRegistration, SortByID:
+		return nil
+	default:
+		return fmt.Errorf("unknown sort order %q, valid orders are: %s, %s", f.SortBy, SortByRegistration, SortByID)
+	}
+}
+
+// QueryRequest returns the items query selecting the items of the filter's statuses, types and ID prefix.
+// The other criteria are applied by ItemRows.
+func (f ItemFilter) QueryRequest() orchestrator.ItemsQueryRequest {
+	return orchestrator.ItemsQueryRequest{Statuses: f.Statuses, Types: f.Types, IDPrefix: f.IDPrefix}
+}
+
+// ItemRows returns the items of state that match the filter, in the filter's order.
+// Queued items have not been registered yet and follow the registered items in queue order.
+func ItemRows(state *orchestrator.QueryResponse, filter ItemFilter) []ItemRow {
+	rows := make([]ItemRow, 0, len(state.OrchestratedItems)+len(state.QueuedItems))
+	for _, item := range state.OrchestratedItems {
+		row := orchestratedItemRow(item)
+		if filter.matches(row) {
+			rows = append(rows, row)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if filter.SortBy != SortByID && !rows[i].RegisteredAt.Equal(*rows[j].RegisteredAt) {
+			return rows[i].RegisteredAt.Before(*rows[j].RegisteredAt)
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	queued := make([]ItemRow, 0, len(state.QueuedItems))
+	for _, item := range state.QueuedItems {
+		row := queuedItemRow(item)
+		if filter.matches(row) {
+			queued = append(queued, row)
+		}
+	}
+	if filter.SortBy == SortByID {
+		sort.SliceStable(queued, func(i, j int) bool { return queued[i].ID < queued[j].ID })
+	}
+	return append(rows, queued...)
+}
+
+func orchestratedItemRow(item orchestrator.OrchestratedItem) ItemRow {
+	registeredAt := item.RegisteredAt
+	row := ItemRow{
+		ID:                item.ID,
+		Type:              orchestrator.ItemTypeName(item.WorkflowName),
+		State:             ItemStateRegistered,
+		Status:            orchestrator.PayloadStatus(item.Payload),
+		RegisteredAt:      &registeredAt,
+		ItemWorkflowID:    item.ItemWorkflowID,
+		ItemWorkflowRunID: item.ItemWorkflowRunID,
+		Completion:        item.Completion,
+		Payload:           item.Payload,
+	}
+	switch {
+	case item.Deregistered:
+		row.State = ItemStateDeregistered
+	case item.InProgress:
+		row.State = ItemStateInProgress
+	}
+	if item.Completion != nil {
+		row.Result = item.Completion.Result
+	}
+	return row
+}
+
+func queuedItemRow(item orchestrator.QueuedItem) ItemRow {
+	return ItemRow{
+		ID:      item.ID,
+		Type:    orchestrator.ItemTypeName(item.WorkflowName),
+		State:   ItemStateQueued,
+		Status:  orchestrator.PayloadStatus(item.Payload),
+		Payload: item.Payload,
+	}
+}
+
+func (f ItemFilter) matches(row ItemRow) bool {
+	if len(f.Statuses) > 0 && !orchestrator.ContainsFold(f.Statuses, row.Status) {
+		return false
+	}
+	if len(f.Types) > 0 && !orchestrator.ContainsFold(f.Types, row.Type) {
+		return false
+	}
+	if !strings.HasPrefix(row.ID, f.IDPrefix) {
+		return false
+	}
+	if f.InProgress != nil && *f.InProgress != (row.State == ItemStateInProgress) {
+		return false
+	}
+	if f.Deregistered != nil && *f.Deregistered != (row.State == ItemStateDeregistered) {
+		return false
+	}
+	return true
+}
+
+func splitList(v string) []string {
+	var values []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// optionalBool is a boolean flag that distinguishes "not set" from false.
+type optionalBool struct {
+	value **bool
+}
+
+func (b *optionalBool) String() string {
+	if b.value == nil || *b.value == nil {
+		return ""
+	}
+	return strconv.FormatBool(**b.value)
+}
+
+func (b *optionalBool) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*b.value = &v
+	return nil
+}
+
+func (b *optionalBool) IsBoolFlag() bool { return true }
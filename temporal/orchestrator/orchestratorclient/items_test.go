@@ -0,0 +1,84 @@
+package orchestratorclient
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"my-samples-go/temporal/orchestrator"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testQueryResponse() *orchestrator.QueryResponse {
+	registeredAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &orchestrator.QueryResponse{
+		TotalItems: 3,
+		OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+			"a-1": {ID: "a-1", WorkflowName: orchestrator.ItemWorkflowAName, RegisteredAt: registeredAt.Add(2 * time.Minute), InProgress: true,
+				Payload: map[string]interface{}{"id": "a-1", "status": "Processing"}},
+			"b-1": {ID: "b-1", WorkflowName: orchestrator.ItemWorkflowBName, RegisteredAt: registeredAt.Add(time.Minute), Deregistered: true,
+				Payload: map[string]interface{}{"id": "b-1", "status": "Completed"}, Completion: &orchestrator.CompletionReport{Result: "Finished Successfully"}},
+			"c-1": {ID: "c-1", WorkflowName: orchestrator.ItemWorkflowAName, RegisteredAt: registeredAt.Add(3 * time.Minute),
+				Payload: map[string]interface{}{"id": "c-1", "status": "New"}},
+		},
+		QueuedItems: []orchestrator.QueuedItem{
+			{ID: "q-2", WorkflowName: orchestrator.ItemWorkflowBName, Payload: map[string]interface{}{"id": "q-2", "status": "New"}},
+			{ID: "q-1", WorkflowName: orchestrator.ItemWorkflowAName, Payload: map[string]interface{}{"id": "q-1", "status": "New"}},
+		},
+	}
+}
+
+func rowIDs(rows []ItemRow) []string {
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	return ids
+}
+
+func Test_ItemRows_Sort(t *testing.T) {
+	rows := ItemRows(testQueryResponse(), ItemFilter{})
+	require.Equal(t, []string{"b-1", "a-1", "c-1", "q-2", "q-1"}, rowIDs(rows))
+	require.Equal(t, ItemStateDeregistered, rows[0].State)
+	require.Equal(t, "Finished Successfully", rows[0].Result)
+	require.Equal(t, "b", rows[0].Type)
+	require.Equal(t, ItemStateInProgress, rows[1].State)
+	require.Equal(t, ItemStateQueued, rows[3].State)
+
+	rows = ItemRows(testQueryResponse(), ItemFilter{SortBy: SortByID})
+	require.Equal(t, []string{"a-1", "b-1", "c-1", "q-1", "q-2"}, rowIDs(rows))
+}
+
+func Test_ItemRows_Filter(t *testing.T) {
+	var filter ItemFilter
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	filter.RegisterFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-type", "A", "-status", "new,processing", "-deregistered=false"}))
+	require.Equal(t, []string{"a-1", "c-1", "q-1"}, rowIDs(ItemRows(testQueryResponse(), filter)))
+
+	filter = ItemFilter{}
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	filter.RegisterFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-in-progress"}))
+	require.Equal(t, []string{"a-1"}, rowIDs(ItemRows(testQueryResponse(), filter)))
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	filter.RegisterFlags(fs)
+	require.Error(t, fs.Parse([]string{"-type", "c"}))
+	require.Error(t, (&ItemFilter{SortBy: "name"}).Validate())
+}
+
+func Test_WriteItems_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteItems(&buf, FormatCSV, ItemRows(testQueryResponse(), ItemFilter{SortBy: SortByID})[:1]))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"id", "type", "state", "status", "result", "registered_at", "workflow_id"},
+		{"a-1", "a", "in-progress", "Processing", "", "2024-01-01T12:02:00Z", ""},
+	}, records)
+}
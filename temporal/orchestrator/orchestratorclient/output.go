@@ -0,0 +1,156 @@
+package orchestratorclient
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+	FormatCSV   = "csv"
+)
+
+var Formats = []string{FormatTable, FormatJSON, FormatYAML, FormatCSV}
+
+// ValidateFormat checks that format is one of Formats.
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q, valid formats are: %s", format, strings.Join(Formats, ", "))
+}
+
+var itemColumns = []string{"ID", "TYPE", "STATE", "STATUS", "RESULT", "REGISTERED AT", "WORKFLOW ID"}
+
+func (r ItemRow) columns() []string {
+	registeredAt := ""
+	if r.RegisteredAt != nil {
+		registeredAt = r.RegisteredAt.Format(time.RFC3339)
+	}
+	return []string{r.ID, r.Type, r.State, r.Status, r.Result, registeredAt, r.ItemWorkflowID}
+}
+
+// WriteItems writes rows to w in the given format. JSON and YAML contain all item details,
+// table and CSV the columns of itemColumns.
+func WriteItems(w io.Writer, format string, rows []ItemRow) error {
+	if format != FormatTable && format != FormatCSV {
+		return WriteValue(w, format, rows)
+	}
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		records[i] = row.columns()
+	}
+	return writeRecords(w, format, itemColumns, records)
+}
+
+var executionColumns = []string{"ITEM ID", "TYPE", "ITEM STATUS", "DECISION", "STATUS", "START TIME", "CLOSE TIME", "WORKFLOW ID"}
+
+func (e ItemExecution) columns() []string {
+	closeTime := ""
+	if e.CloseTime != nil {
+		closeTime = e.CloseTime.Format(time.RFC3339)
+	}
+	return []string{e.ItemID, e.Type, e.ItemStatus, e.Decision, e.Status, e.StartTime.Format(time.RFC3339), closeTime, e.WorkflowID}
+}
+
+// WriteExecutions writes item workflow executions to w in the given format. JSON and YAML contain all details,
+// table and CSV the columns of executionColumns.
+func WriteExecutions(w io.Writer, format string, executions []ItemExecution) error {
+	if format != FormatTable && format != FormatCSV {
+		return WriteValue(w, format, executions)
+	}
+	records := make([][]string, len(executions))
+	for i, execution := range executions {
+		records[i] = execution.columns()
+	}
+	return writeRecords(w, format, executionColumns, records)
+}
+
+var scheduleColumns = []string{"ID", "TYPE", "PAUSED", "NEXT ACTION", "LAST ACTION", "LAST WORKFLOW ID", "NOTE"}
+
+func (s ScheduleInfo) columns() []string {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	return []string{s.ID, s.Type, fmt.Sprint(s.Paused), formatTime(s.NextAction), formatTime(s.LastAction), s.LastWorkflowID, s.Note}
+}
+
+// WriteSchedules writes item schedules to w in the given format. JSON and YAML contain all details,
+// table and CSV the columns of scheduleColumns.
+func WriteSchedules(w io.Writer, format string, schedules []ScheduleInfo) error {
+	if format != FormatTable && format != FormatCSV {
+		return WriteValue(w, format, schedules)
+	}
+	records := make([][]string, len(schedules))
+	for i, schedule := range schedules {
+		records[i] = schedule.columns()
+	}
+	return writeRecords(w, format, scheduleColumns, records)
+}
+
+// writeRecords writes a table or CSV. The CSV header uses the column names in snake case.
+func writeRecords(w io.Writer, format string, columns []string, records [][]string) error {
+	if format == FormatTable {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(columns, "\t"))
+		for _, record := range records {
+			fmt.Fprintln(tw, strings.Join(record, "\t"))
+		}
+		return tw.Flush()
+	}
+
+	cw := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToLower(strings.ReplaceAll(column, " ", "_"))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// WriteValue writes v to w as JSON or YAML. The YAML output uses the same field names as the JSON output.
+func WriteValue(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatYAML:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(generic); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("output format %q is not supported here, use %s or %s", format, FormatJSON, FormatYAML)
+	}
+}
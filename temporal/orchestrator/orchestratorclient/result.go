@@ -0,0 +1,60 @@
+package orchestratorclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+// ErrItemRunning is returned when the item workflow has not finished yet, or not within the timeout.
+var ErrItemRunning = errors.New("item workflow has not finished")
+
+// ItemWorkflow returns the workflow ID and run ID of an item tracked by the orchestrator.
+// A queued item has no workflow yet. With a positive pollInterval the orchestrator is polled until the item
+// was launched or ctx is done, otherwise ErrItemRunning is returned right away.
+func (c *Client) ItemWorkflow(ctx context.Context, itemID string, pollInterval time.Duration) (string, string, error) {
+	for {
+		item, _, err := c.FindItem(ctx, itemID)
+		if err != nil {
+			return "", "", err
+		}
+		if item != nil {
+			return item.ItemWorkflowID, item.ItemWorkflowRunID, nil
+		}
+		if pollInterval <= 0 {
+			return "", "", fmt.Errorf("%w: item %s is still queued", ErrItemRunning, itemID)
+		}
+		select {
+		case <-ctx.Done():
+			return "", "", fmt.Errorf("%w: item %s is still queued", ErrItemRunning, itemID)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// ItemResult returns the result of an item workflow. An empty runID uses the latest run.
+// With wait, it blocks until the workflow finishes or ctx is done, otherwise a running workflow returns ErrItemRunning.
+func (c *Client) ItemResult(ctx context.Context, workflowID string, runID string, wait bool) (string, error) {
+	if !wait {
+		resp, err := c.Temporal.DescribeWorkflowExecution(ctx, workflowID, runID)
+		if err != nil {
+			return "", err
+		}
+		if resp.GetWorkflowExecutionInfo().GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			return "", fmt.Errorf("%w: %s", ErrItemRunning, workflowID)
+		}
+	}
+
+	var result string
+	err := c.Temporal.GetWorkflow(ctx, workflowID, runID).Get(ctx, &result)
+	if err != nil && ctx.Err() != nil {
+		return "", fmt.Errorf("%w: %s", ErrItemRunning, workflowID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("workflow failed: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package orchestratorclient
+
+import (
+	"context"
+	"errors"
+	"my-samples-go/temporal/orchestrator"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/mocks"
+)
+
+// expectState answers one lookup of item-1 from each of the states.
+func expectState(t *testing.T, c *mocks.Client, states ...orchestrator.QueryResponse) {
+	for _, state := range states {
+		value := mocks.NewEncodedValue(t)
+		value.On("Get", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*orchestrator.ItemLookupResponse) = orchestrator.LookupItem(state.OrchestratedItems, state.QueuedItems, "item-1")
+		}).Return(nil).Once()
+		c.On("QueryWorkflow", mock.Anything, orchestrator.OrchestratorWorkflowID, "", orchestrator.ItemLookupQueryName, "item-1").Return(value, nil).Once()
+	}
+}
+
+func expectStatus(c *mocks.Client, status enumspb.WorkflowExecutionStatus) {
+	c.On("DescribeWorkflowExecution", mock.Anything, "item_item-1_x", "").Return(&workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{Status: status},
+	}, nil).Once()
+}
+
+func Test_ItemWorkflow(t *testing.T) {
+	c := mocks.NewClient(t)
+	queued := orchestrator.QueryResponse{QueuedItems: []orchestrator.QueuedItem{{ID: "item-1"}}}
+	launched := orchestrator.QueryResponse{OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+		"item-1": {ID: "item-1", ItemWorkflowID: "item_item-1_x", ItemWorkflowRunID: "run"},
+	}}
+	oc := New(c, "")
+
+	// Without polling a queued item is reported as not finished.
+	expectState(t, c, queued)
+	_, _, err := oc.ItemWorkflow(context.Background(), "item-1", 0)
+	require.ErrorIs(t, err, ErrItemRunning)
+
+	expectState(t, c, queued, launched)
+	workflowID, runID, err := oc.ItemWorkflow(context.Background(), "item-1", time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, "item_item-1_x", workflowID)
+	require.Equal(t, "run", runID)
+}
+
+func Test_ItemResult(t *testing.T) {
+	c := mocks.NewClient(t)
+	oc := New(c, "")
+
+	// Without waiting, a running workflow is not waited for.
+	expectStatus(c, enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING)
+	_, err := oc.ItemResult(context.Background(), "item_item-1_x", "", false)
+	require.ErrorIs(t, err, ErrItemRunning)
+
+	run := mocks.NewWorkflowRun(t)
+	run.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(1).(*string) = "Finished Successfully"
+	}).Return(nil).Once()
+	c.On("GetWorkflow", mock.Anything, "item_item-1_x", "").Return(run)
+	expectStatus(c, enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED)
+	result, err := oc.ItemResult(context.Background(), "item_item-1_x", "", false)
+	require.NoError(t, err)
+	require.Equal(t, "Finished Successfully", result)
+
+	run.On("Get", mock.Anything, mock.Anything).Return(errors.New("Halted by orchestrator")).Once()
+	_, err = oc.ItemResult(context.Background(), "item_item-1_x", "", true)
+	require.ErrorContains(t, err, "Halted by orchestrator")
+	require.NotErrorIs(t, err, ErrItemRunning)
+
+	// A wait that times out is reported as not finished.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	run.On("Get", mock.Anything, mock.Anything).Return(context.Canceled).Once()
+	_, err = oc.ItemResult(ctx, "item_item-1_x", "", true)
+	require.ErrorIs(t, err, ErrItemRunning)
+}
@@ -0,0 +1,395 @@
+package orchestratorclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/schema"
+	"os"
+	"sort"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"gopkg.in/yaml.v3"
+)
+
+// Overlap policies of schedules. A schedule starts an orchestrator.ScheduledItemWorkflow, which only submits the
+// item to the orchestrator queue, so runs overlap only while the worker is unavailable. Only the policies that skip
+// or buffer the overlapping run are allowed: cancelling or terminating the previous run could lose its item.
+const (
+	OverlapSkip      = "skip"
+	OverlapBufferOne = "buffer-one"
+	OverlapBufferAll = "buffer-all"
+)
+
+var overlapPolicies = map[string]enumspb.ScheduleOverlapPolicy{
+	OverlapSkip:      enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	OverlapBufferOne: enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ONE,
+	OverlapBufferAll: enumspb.SCHEDULE_OVERLAP_POLICY_BUFFER_ALL,
+}
+
+// scheduleDefinitionMemo is the memo key of the schedule action holding the definition the schedule was
+// created or last updated from, which SyncSchedules compares against.
+const scheduleDefinitionMemo = "scheduleDefinition"
+
+// ScheduleDefinition declares a Temporal Schedule that periodically submits an item to the orchestrator (pull model).
+type ScheduleDefinition struct {
+	ID       string            `yaml:"id" json:"id"`
+	Type     string            `yaml:"type" json:"type"`                     // item type name, see orchestrator.ItemTypes
+	ItemID   string            `yaml:"itemId" json:"itemId"`                 // item ID template, e.g. "report-{{.Date}}", see orchestrator.ItemIDTemplateData
+	Name     string            `yaml:"name,omitempty" json:"name,omitempty"` // item name, the schedule ID if empty
+	Fields   map[string]string `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Cron     []string          `yaml:"cron,omitempty" json:"cron,omitempty"`
+	Interval time.Duration     `yaml:"interval,omitempty" json:"interval,omitempty"`
+	TimeZone string            `yaml:"timeZone,omitempty" json:"timeZone,omitempty"` // of the cron expressions, UTC if empty
+	Overlap  string            `yaml:"overlap,omitempty" json:"overlap,omitempty"`   // OverlapSkip (default), OverlapBufferOne or OverlapBufferAll
+	Paused   bool              `yaml:"paused,omitempty" json:"paused,omitempty"`
+	Note     string            `yaml:"note,omitempty" json:"note,omitempty"`
+}
+
+// Validate checks the definition and normalizes the type name and overlap policy.
+func (d *ScheduleDefinition) Validate() error {
+	if d.ID == "" {
+		return errors.New("schedule id is required")
+	}
+	itemType, err := orchestrator.ItemTypes.Lookup(d.Type)
+	if err != nil {
+		return fmt.Errorf("schedule %s: %w", d.ID, err)
+	}
+	d.Type = itemType.Name
+	if _, err := itemType.FieldValues(d.Fields); err != nil {
+		return fmt.Errorf("schedule %s: %w", d.ID, err)
+	}
+	if d.ItemID == "" {
+		return fmt.Errorf("schedule %s: itemId is required", d.ID)
+	}
+	if _, err := orchestrator.ExpandItemID(d.ItemID, orchestrator.NewItemIDTemplateData(d.ID, time.Now())); err != nil {
+		return fmt.Errorf("schedule %s: %w", d.ID, err)
+	}
+	if len(d.Cron) == 0 && d.Interval == 0 {
+		return fmt.Errorf("schedule %s: cron or interval is required", d.ID)
+	}
+	if d.Interval < 0 {
+		return fmt.Errorf("schedule %s: interval must not be negative", d.ID)
+	}
+	if _, err := time.LoadLocation(d.TimeZone); err != nil {
+		return fmt.Errorf("schedule %s: %w", d.ID, err)
+	}
+	if d.Overlap == "" {
+		d.Overlap = OverlapSkip
+	}
+	if _, valid := overlapPolicies[d.Overlap]; !valid {
+		return fmt.Errorf("schedule %s: overlap policy %q does not fit the orchestrator, valid policies are: %s, %s, %s",
+			d.ID, d.Overlap, OverlapSkip, OverlapBufferOne, OverlapBufferAll)
+	}
+	return nil
+}
+
+// LoadScheduleFile reads the schedule definitions from a YAML file with a top level "schedules" list,
+// and validates them.
+func LoadScheduleFile(path string) ([]ScheduleDefinition, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var file struct {
+		Schedules []ScheduleDefinition `yaml:"schedules"`
+	}
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("unable to decode schedule file %s: %w", path, err)
+	}
+	ids := make(map[string]bool, len(file.Schedules))
+	for i := range file.Schedules {
+		def := &file.Schedules[i]
+		if err := def.Validate(); err != nil {
+			return nil, err
+		}
+		if ids[def.ID] {
+			return nil, fmt.Errorf("schedule %s is defined twice", def.ID)
+		}
+		ids[def.ID] = true
+	}
+	return file.Schedules, nil
+}
+
+// FindSchedule returns the definition with the given ID.
+func FindSchedule(defs []ScheduleDefinition, id string) (ScheduleDefinition, error) {
+	for _, def := range defs {
+		if def.ID == id {
+			return def, nil
+		}
+	}
+	return ScheduleDefinition{}, fmt.Errorf("schedule %s is not defined", id)
+}
+
+// scheduleOptions returns the schedule described by the validated definition.
+func (c *Client) scheduleOptions(def ScheduleDefinition) (client.ScheduleSpec, *client.ScheduleWorkflowAction, error) {
+	spec := client.ScheduleSpec{CronExpressions: def.Cron, TimeZoneName: def.TimeZone}
+	if def.Interval > 0 {
+		spec.Intervals = []client.ScheduleIntervalSpec{{Every: def.Interval}}
+	}
+
+	itemType, err := orchestrator.ItemTypes.Lookup(def.Type)
+	if err != nil {
+		return spec, nil, err
+	}
+	fields, err := itemType.FieldValues(def.Fields)
+	if err != nil {
+		return spec, nil, err
+	}
+	name := def.Name
+	if name == "" {
+		name = def.ID
+	}
+	definition, err := json.Marshal(def)
+	if err != nil {
+		return spec, nil, err
+	}
+	submit := orchestrator.SubmitPayload{
+		ID:           def.ItemID, // expanded by the scheduled workflow
+		WorkflowName: itemType.WorkflowName,
+		Item:         itemType.NewItem(def.ItemID, name, fields),
+	}
+	return spec, &client.ScheduleWorkflowAction{
+		ID:        "schedule_" + def.ID, // Temporal appends the scheduled time
+		Workflow:  orchestrator.ScheduledItemWorkflowName,
+		Args:      []interface{}{submit},
+		TaskQueue: c.TaskQueue,
+		Memo:      map[string]interface{}{scheduleDefinitionMemo: string(definition)},
+	}, nil
+}
+
+// SubmitItemActivity implements the orchestrator.SubmitItemActivityName activity of the scheduled workflows.
+// Items not matching their schema are not retried.
+func (c *Client) SubmitItemActivity(ctx context.Context, submit orchestrator.SubmitPayload) error {
+	err := c.SignalWithStart(ctx, orchestrator.Signal{Type: orchestrator.SubmitSignal, Payload: submit})
+	if errors.Is(err, schema.ErrInvalid) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "InvalidItem", err)
+	}
+	return err
+}
+
+// CreateSchedule creates the schedule of a validated definition.
+func (c *Client) CreateSchedule(ctx context.Context, def ScheduleDefinition) error {
+	spec, action, err := c.scheduleOptions(def)
+	if err != nil {
+		return err
+	}
+	_, err = c.Temporal.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:      def.ID,
+		Spec:    spec,
+		Action:  action,
+		Overlap: overlapPolicies[def.Overlap],
+		Paused:  def.Paused,
+		Note:    def.Note,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create schedule %s: %w", def.ID, err)
+	}
+	return nil
+}
+
+// UpdateSchedule replaces the spec, action, overlap policy and pause state of an existing schedule
+// with those of a validated definition.
+func (c *Client) UpdateSchedule(ctx context.Context, def ScheduleDefinition) error {
+	spec, action, err := c.scheduleOptions(def)
+	if err != nil {
+		return err
+	}
+	err = c.Temporal.ScheduleClient().GetHandle(ctx, def.ID).Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			schedule.Spec = &spec
+			schedule.Action = action
+			if schedule.Policy == nil {
+				schedule.Policy = &client.SchedulePolicies{}
+			}
+			schedule.Policy.Overlap = overlapPolicies[def.Overlap]
+			if schedule.State == nil {
+				schedule.State = &client.ScheduleState{}
+			}
+			schedule.State.Paused = def.Paused
+			schedule.State.Note = def.Note
+			return &client.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update schedule %s: %w", def.ID, err)
+	}
+	return nil
+}
+
+// DeleteSchedule deletes a schedule. Items it submitted are still processed.
+func (c *Client) DeleteSchedule(ctx context.Context, id string) error {
+	if err := c.Temporal.ScheduleClient().GetHandle(ctx, id).Delete(ctx); err != nil {
+		return fmt.Errorf("unable to delete schedule %s: %w", id, err)
+	}
+	return nil
+}
+
+// ScheduleInfo describes a schedule of items.
+type ScheduleInfo struct {
+	ID             string     `json:"id"`
+	Type           string     `json:"type"`
+	Paused         bool       `json:"paused"`
+	Note           string     `json:"note,omitempty"`
+	NextAction     *time.Time `json:"nextAction,omitempty"`
+	LastAction     *time.Time `json:"lastAction,omitempty"`
+	LastWorkflowID string     `json:"lastWorkflowId,omitempty"`
+}
+
+// ListSchedules lists the schedules of items, sorted by ID. They include the schedules that start item workflows
+// directly, as created before schedules submitted their items, which sync updates. The list is eventually consistent.
+func (c *Client) ListSchedules(ctx context.Context) ([]ScheduleInfo, error) {
+	iter, err := c.Temporal.ScheduleClient().List(ctx, client.ScheduleListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list schedules: %w", err)
+	}
+	var schedules []ScheduleInfo
+	for iter.HasNext() {
+		entry, err := iter.Next()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list schedules: %w", err)
+		}
+		info := ScheduleInfo{ID: entry.ID, Paused: entry.Paused, Note: entry.Note}
+		if entry.WorkflowType.Name == orchestrator.ScheduledItemWorkflowName {
+			// List entries do not hold the action, the item type is part of the definition.
+			definition, _, err := c.scheduleDefinition(ctx, entry.ID)
+			if err != nil {
+				return nil, err
+			}
+			var def ScheduleDefinition
+			if json.Unmarshal([]byte(definition), &def) == nil {
+				info.Type = def.Type
+			}
+		} else if itemType, isItem := orchestrator.ItemTypes.LookupWorkflow(entry.WorkflowType.Name); isItem {
+			info.Type = itemType.Name
+		} else {
+			continue
+		}
+		if len(entry.NextActionTimes) > 0 {
+			info.NextAction = &entry.NextActionTimes[0]
+		}
+		if n := len(entry.RecentActions); n > 0 {
+			last := entry.RecentActions[n-1]
+			info.LastAction = &last.ScheduleTime
+			if last.StartWorkflowResult != nil {
+				info.LastWorkflowID = last.StartWorkflowResult.WorkflowID
+			}
+		}
+		schedules = append(schedules, info)
+	}
+	sort.Slice(schedules, func(i, j int) bool { return schedules[i].ID < schedules[j].ID })
+	return schedules, nil
+}
+
+// Schedule changes made by SyncSchedules
+const (
+	ScheduleCreated   = "created"
+	ScheduleUpdated   = "updated"
+	ScheduleDeleted   = "deleted"
+	ScheduleUnchanged = "unchanged"
+)
+
+// ScheduleChange is a change SyncSchedules made, or would make in a dry run.
+type ScheduleChange struct {
+	ID     string `json:"id"`
+	Change string `json:"change"`
+}
+
+// SyncOptions control SyncSchedules.
+type SyncOptions struct {
+	Prune  bool // delete the schedules of items that are not defined
+	DryRun bool // only report the changes
+}
+
+// SyncSchedules reconciles the schedules on the server with the validated definitions: missing schedules are
+// created, and schedules whose definition changed since they were created or last updated are updated.
+func (c *Client) SyncSchedules(ctx context.Context, defs []ScheduleDefinition, opts SyncOptions) ([]ScheduleChange, error) {
+	var changes []ScheduleChange
+	defined := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		defined[def.ID] = true
+		current, exists, err := c.scheduleDefinition(ctx, def.ID)
+		if err != nil {
+			return changes, err
+		}
+		change := ScheduleChange{ID: def.ID, Change: ScheduleUnchanged}
+		switch {
+		case !exists:
+			change.Change = ScheduleCreated
+			if !opts.DryRun {
+				err = c.CreateSchedule(ctx, def)
+			}
+		case current != def.key():
+			change.Change = ScheduleUpdated
+			if !opts.DryRun {
+				err = c.UpdateSchedule(ctx, def)
+			}
+		}
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, change)
+	}
+
+	if opts.Prune {
+		existing, err := c.ListSchedules(ctx)
+		if err != nil {
+			return changes, err
+		}
+		for _, schedule := range existing {
+			if defined[schedule.ID] {
+				continue
+			}
+			if !opts.DryRun {
+				if err := c.DeleteSchedule(ctx, schedule.ID); err != nil {
+					return changes, err
+				}
+			}
+			changes = append(changes, ScheduleChange{ID: schedule.ID, Change: ScheduleDeleted})
+		}
+	}
+	return changes, nil
+}
+
+// key returns the JSON form of the definition stored in the schedule action's memo.
+func (d ScheduleDefinition) key() string {
+	data, _ := json.Marshal(d)
+	return string(data)
+}
+
+// scheduleDefinition returns the definition key stored in an existing schedule, empty if the schedule
+// was not created from a definition or starts item workflows directly.
+func (c *Client) scheduleDefinition(ctx context.Context, id string) (string, bool, error) {
+	desc, err := c.Temporal.ScheduleClient().GetHandle(ctx, id).Describe(ctx)
+	var notFound *serviceerror.NotFound
+	if errors.As(err, &notFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("unable to describe schedule %s: %w", id, err)
+	}
+	action, ok := desc.Schedule.Action.(*client.ScheduleWorkflowAction)
+	if !ok || action.Workflow != orchestrator.ScheduledItemWorkflowName {
+		return "", true, nil
+	}
+	payload, ok := action.Memo[scheduleDefinitionMemo].(*commonpb.Payload)
+	if !ok {
+		return "", true, nil
+	}
+	var definition string
+	if err := c.DataConverter.FromPayload(payload, &definition); err != nil {
+		return "", true, nil
+	}
+	return definition, true, nil
+}
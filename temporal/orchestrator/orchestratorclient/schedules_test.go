@@ -0,0 +1,168 @@
+package orchestratorclient
+
+import (
+	"context"
+	"my-samples-go/temporal/orchestrator"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/mocks"
+)
+
+func Test_LoadScheduleFile(t *testing.T) {
+	defs, err := LoadScheduleFile("../schedules.yaml")
+	require.NoError(t, err)
+	require.Len(t, defs, 2)
+	require.Equal(t, "a", defs[0].Type)
+	require.Equal(t, OverlapSkip, defs[0].Overlap)
+	require.Equal(t, time.Hour, defs[1].Interval)
+	require.Equal(t, OverlapBufferOne, defs[1].Overlap)
+
+	write := func(content string) string {
+		path := filepath.Join(t.TempDir(), "schedules.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	_, err = LoadScheduleFile(write("schedules:\n  - id: s\n    type: a\n    itemId: x\n    interval: 1h\n  - id: s\n    type: b\n    itemId: y\n    interval: 1h\n"))
+	require.ErrorContains(t, err, "defined twice")
+	_, err = LoadScheduleFile(write("schedules:\n  - id: s\n    kind: a\n"))
+	require.ErrorContains(t, err, "field kind not found")
+}
+
+func Test_ScheduleDefinition_Validate(t *testing.T) {
+	valid := func() ScheduleDefinition {
+		return ScheduleDefinition{ID: "daily", Type: "A", ItemID: "report-{{.Date}}", Cron: []string{"0 6 * * *"}}
+	}
+	def := valid()
+	require.NoError(t, def.Validate())
+	require.Equal(t, "a", def.Type)
+	require.Equal(t, OverlapSkip, def.Overlap)
+
+	for _, tc := range []struct {
+		change func(d *ScheduleDefinition)
+		err    string
+	}{
+		{func(d *ScheduleDefinition) { d.ID = "" }, "schedule id is required"},
+		{func(d *ScheduleDefinition) { d.Type = "c" }, "unknown item type"},
+		{func(d *ScheduleDefinition) { d.Fields = map[string]string{"extra-b": "x"} }, "extra-b"},
+		{func(d *ScheduleDefinition) { d.ItemID = "" }, "itemId is required"},
+		{func(d *ScheduleDefinition) { d.ItemID = "report-{{.Day}}" }, "Day"},
+		{func(d *ScheduleDefinition) { d.Cron = nil }, "cron or interval is required"},
+		{func(d *ScheduleDefinition) { d.Interval = -time.Hour }, "must not be negative"},
+		{func(d *ScheduleDefinition) { d.TimeZone = "Mars/Olympus" }, "unknown time zone"},
+		{func(d *ScheduleDefinition) { d.Overlap = "terminate-other" }, "does not fit the orchestrator"},
+		{func(d *ScheduleDefinition) { d.Overlap = "allow-all" }, "does not fit the orchestrator"},
+	} {
+		def := valid()
+		tc.change(&def)
+		require.ErrorContains(t, def.Validate(), tc.err)
+	}
+}
+
+// describeSchedule returns the description of a schedule created from def.
+func describeSchedule(t *testing.T, def ScheduleDefinition) *client.ScheduleDescription {
+	payload, err := converter.GetDefaultDataConverter().ToPayload(def.key())
+	require.NoError(t, err)
+	return &client.ScheduleDescription{Schedule: client.Schedule{
+		Action: &client.ScheduleWorkflowAction{
+			Workflow: orchestrator.ScheduledItemWorkflowName,
+			Memo:     map[string]interface{}{scheduleDefinitionMemo: payload},
+		},
+	}}
+}
+
+func Test_SyncSchedules(t *testing.T) {
+	c := mocks.NewClient(t)
+	sc := mocks.NewScheduleClient(t)
+	c.On("ScheduleClient").Return(sc)
+	oc := New(c, "test-queue")
+
+	defs := []ScheduleDefinition{
+		{ID: "new", Type: "a", ItemID: "new-{{.Date}}", Interval: time.Hour},
+		{ID: "same", Type: "a", ItemID: "same-{{.Date}}", Interval: time.Hour},
+		{ID: "changed", Type: "b", ItemID: "changed-{{.Date}}", Cron: []string{"0 6 * * *"}},
+	}
+	for i := range defs {
+		require.NoError(t, defs[i].Validate())
+	}
+	changedBefore := defs[2]
+	changedBefore.Cron = []string{"0 7 * * *"}
+
+	handle := func(id string, desc *client.ScheduleDescription, err error) *mocks.ScheduleHandle {
+		h := mocks.NewScheduleHandle(t)
+		h.On("Describe", mock.Anything).Return(desc, err).Twice()
+		sc.On("GetHandle", mock.Anything, id).Return(h)
+		return h
+	}
+	handle("new", nil, serviceerror.NewNotFound("schedule not found"))
+	handle("same", describeSchedule(t, defs[1]), nil)
+	changed := handle("changed", describeSchedule(t, changedBefore), nil)
+	// Schedules that start the item workflow directly are updated even if their definition did not change.
+	pushed := describeSchedule(t, defs[1])
+	pushed.Schedule.Action.(*client.ScheduleWorkflowAction).Workflow = orchestrator.ItemWorkflowAName
+	legacy := handle("legacy", pushed, nil)
+	defs = append(defs, ScheduleDefinition{ID: "legacy", Type: "a", ItemID: "legacy-{{.Date}}", Interval: time.Hour})
+	require.NoError(t, defs[3].Validate())
+	legacy.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+	listEntry := func(id, workflowName string) *client.ScheduleListEntry {
+		entry := &client.ScheduleListEntry{ID: id}
+		entry.WorkflowType.Name = workflowName
+		return entry
+	}
+	entries := mocks.NewScheduleListIterator(t)
+	entries.On("HasNext").Return(true).Times(3)
+	entries.On("HasNext").Return(false).Once()
+	entries.On("Next").Return(listEntry("unknown", orchestrator.ItemWorkflowAName), nil).Once()
+	entries.On("Next").Return(listEntry("undefined", orchestrator.ScheduledItemWorkflowName), nil).Once()
+	entries.On("Next").Return(listEntry("other", "OtherWorkflow"), nil).Once()
+	undefined := mocks.NewScheduleHandle(t)
+	undefined.On("Describe", mock.Anything).Return(describeSchedule(t, defs[0]), nil).Once()
+	sc.On("GetHandle", mock.Anything, "undefined").Return(undefined)
+	sc.On("List", mock.Anything, mock.Anything).Return(entries, nil).Once()
+
+	// A dry run only reports the changes.
+	changes, err := oc.SyncSchedules(context.Background(), defs, SyncOptions{Prune: true, DryRun: true})
+	require.NoError(t, err)
+	expected := []ScheduleChange{
+		{ID: "new", Change: ScheduleCreated},
+		{ID: "same", Change: ScheduleUnchanged},
+		{ID: "changed", Change: ScheduleUpdated},
+		{ID: "legacy", Change: ScheduleUpdated},
+		{ID: "undefined", Change: ScheduleDeleted},
+		{ID: "unknown", Change: ScheduleDeleted},
+	}
+	require.Equal(t, expected, changes)
+
+	sc.On("Create", mock.Anything, mock.MatchedBy(func(options client.ScheduleOptions) bool {
+		action := options.Action.(*client.ScheduleWorkflowAction)
+		submit := action.Args[0].(orchestrator.SubmitPayload)
+		item := submit.Item.(*orchestrator.ItemA)
+		return options.ID == "new" && options.Overlap == enumspb.SCHEDULE_OVERLAP_POLICY_SKIP &&
+			action.ID == "schedule_new" && action.Workflow == orchestrator.ScheduledItemWorkflowName && action.TaskQueue == "test-queue" &&
+			submit.ID == "new-{{.Date}}" && submit.WorkflowName == orchestrator.ItemWorkflowAName &&
+			item.Id == "new-{{.Date}}" && item.Name == "new" && item.ExtraFieldA == "Extra data for Item A"
+	})).Return(nil, nil).Once()
+	changed.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		update, err := args.Get(1).(client.ScheduleUpdateOptions).DoUpdate(client.ScheduleUpdateInput{Description: *describeSchedule(t, changedBefore)})
+		require.NoError(t, err)
+		require.Equal(t, []string{"0 6 * * *"}, update.Schedule.Spec.CronExpressions)
+		action := update.Schedule.Action.(*client.ScheduleWorkflowAction)
+		require.Equal(t, orchestrator.ScheduledItemWorkflowName, action.Workflow)
+		require.Equal(t, orchestrator.ItemWorkflowBName, action.Args[0].(orchestrator.SubmitPayload).WorkflowName)
+		require.Equal(t, enumspb.SCHEDULE_OVERLAP_POLICY_SKIP, update.Schedule.Policy.Overlap)
+	}).Return(nil).Once()
+
+	// Without pruning, schedules that are not defined are kept.
+	changes, err = oc.SyncSchedules(context.Background(), defs, SyncOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expected[:4], changes)
+}
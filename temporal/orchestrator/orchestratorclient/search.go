@@ -0,0 +1,230 @@
+package orchestratorclient
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"my-samples-go/temporal/orchestrator"
+	"strings"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// ItemExecution is an item workflow execution found through its search attributes.
+type ItemExecution struct {
+	ItemID     string     `json:"itemId"`
+	Type       string     `json:"type"`
+	ItemStatus string     `json:"itemStatus"`
+	Decision   string     `json:"decision"`
+	WorkflowID string     `json:"workflowId"`
+	RunID      string     `json:"runId"`
+	Status     string     `json:"status"` // execution status, e.g. Running, Completed or Failed
+	StartTime  time.Time  `json:"startTime"`
+	CloseTime  *time.Time `json:"closeTime,omitempty"`
+}
+
+// SearchFilter selects item workflows by their search attributes. Empty fields match all item workflows.
+type SearchFilter struct {
+	ItemIDs   []string
+	Types     []string
+	Statuses  []string // item statuses, see orchestrator.ItemStatuses
+	Decisions []string // orchestrator decisions, see orchestrator.OrchestratorDecisions
+	Since     time.Duration
+	Query     string // additional visibility query, combined with AND
+}
+
+// RegisterFlags registers the filter flags on fs.
+func (f *SearchFilter) RegisterFlags(fs *flag.FlagSet) {
+	fs.Func("id", "only items with these IDs, comma separated", func(v string) error {
+		f.ItemIDs = append(f.ItemIDs, splitList(v)...)
+		return nil
+	})
+	fs.Func("type", "only items of these types, comma separated: "+strings.Join(orchestrator.ItemTypes.Names(), ", "), func(v string) error {
+		f.Types = append(f.Types, splitList(v)...)
+		return nil
+	})
+	fs.Func("item-status", "only items with these item statuses, comma separated, e.g. Failed", func(v string) error {
+		f.Statuses = append(f.Statuses, splitList(v)...)
+		return nil
+	})
+	fs.Func("decision", "only items with these last orchestrator decisions, comma separated, e.g. Denied", func(v string) error {
+		f.Decisions = append(f.Decisions, splitList(v)...)
+		return nil
+	})
+	fs.DurationVar(&f.Since, "since", 0, "only item workflows started within this duration, e.g. 168h")
+	fs.StringVar(&f.Query, "query", "", "additional visibility query, e.g. \"ExecutionStatus = 'Failed'\"")
+}
+
+// Validate checks the filter values and normalizes them to the values stored in the search attributes.
+func (f *SearchFilter) Validate() error {
+	for i, name := range f.Types {
+		itemType, err := orchestrator.ItemTypes.Lookup(name)
+		if err != nil {
+			return err
+		}
+		f.Types[i] = itemType.Name
+	}
+	statuses := make([]string, len(orchestrator.ItemStatuses))
+	for i, status := range orchestrator.ItemStatuses {
+		statuses[i] = status.String()
+	}
+	if err := normalizeValues(f.Statuses, statuses, "item status"); err != nil {
+		return err
+	}
+	decisions := make([]string, len(orchestrator.OrchestratorDecisions))
+	for i, decision := range orchestrator.OrchestratorDecisions {
+		decisions[i] = decision.String()
+	}
+	if err := normalizeValues(f.Decisions, decisions, "decision"); err != nil {
+		return err
+	}
+	if f.Since < 0 {
+		return fmt.Errorf("since must not be negative")
+	}
+	return nil
+}
+
+// normalizeValues replaces the values with the valid value they match case-insensitively.
+func normalizeValues(values []string, valid []string, name string) error {
+	for i, value := range values {
+		found := false
+		for _, v := range valid {
+			if strings.EqualFold(value, v) {
+				values[i], found = v, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown %s %q, valid values are: %s", name, value, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+// VisibilityQuery returns the List Filter query selecting the item workflows, now is the reference for Since.
+func (f SearchFilter) VisibilityQuery(now time.Time) string {
+	var workflowNames []string
+	for _, itemType := range orchestrator.ItemTypes.Types() {
+		workflowNames = append(workflowNames, itemType.WorkflowName)
+	}
+	conditions := []string{inCondition("WorkflowType", workflowNames)}
+	if len(f.ItemIDs) > 0 {
+		conditions = append(conditions, inCondition(orchestrator.ItemIDSearchAttribute.GetName(), f.ItemIDs))
+	}
+	if len(f.Types) > 0 {
+		conditions = append(conditions, inCondition(orchestrator.ItemTypeSearchAttribute.GetName(), f.Types))
+	}
+	if len(f.Statuses) > 0 {
+		conditions = append(conditions, inCondition(orchestrator.ItemStatusSearchAttribute.GetName(), f.Statuses))
+	}
+	if len(f.Decisions) > 0 {
+		conditions = append(conditions, inCondition(orchestrator.OrchestratorDecisionSearchAttribute.GetName(), f.Decisions))
+	}
+	if f.Since > 0 {
+		conditions = append(conditions, fmt.Sprintf("StartTime > %s", quote(now.Add(-f.Since).UTC().Format(time.RFC3339))))
+	}
+	if f.Query != "" {
+		conditions = append(conditions, "("+f.Query+")")
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func inCondition(attribute string, values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quote(value)
+	}
+	if len(quoted) == 1 {
+		return attribute + " = " + quoted[0]
+	}
+	return attribute + " IN (" + strings.Join(quoted, ", ") + ")"
+}
+
+func quote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "\\'") + "'"
+}
+
+// SearchItems lists the item workflows matching filter through the visibility store, newest first.
+// At most limit executions are returned, all if limit is zero.
+func (c *Client) SearchItems(ctx context.Context, filter SearchFilter, limit int) ([]ItemExecution, error) {
+	request := &workflowservice.ListWorkflowExecutionsRequest{Query: filter.VisibilityQuery(time.Now())}
+	var executions []ItemExecution
+	for {
+		resp, err := c.Temporal.ListWorkflow(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list item workflows: %w", err)
+		}
+		for _, info := range resp.GetExecutions() {
+			executions = append(executions, itemExecution(info))
+			if limit > 0 && len(executions) >= limit {
+				return executions, nil
+			}
+		}
+		if len(resp.GetNextPageToken()) == 0 {
+			return executions, nil
+		}
+		request.NextPageToken = resp.GetNextPageToken()
+	}
+}
+
+func itemExecution(info *workflowpb.WorkflowExecutionInfo) ItemExecution {
+	execution := ItemExecution{
+		WorkflowID: info.GetExecution().GetWorkflowId(),
+		RunID:      info.GetExecution().GetRunId(),
+		Status:     info.GetStatus().String(),
+		StartTime:  info.GetStartTime().AsTime(),
+	}
+	if info.GetCloseTime() != nil {
+		closeTime := info.GetCloseTime().AsTime()
+		execution.CloseTime = &closeTime
+	}
+	fields := info.GetSearchAttributes().GetIndexedFields()
+	keyword := func(name string) string {
+		var value string
+		if payload, exists := fields[name]; exists {
+			_ = converter.GetDefaultDataConverter().FromPayload(payload, &value)
+		}
+		return value
+	}
+	execution.ItemID = keyword(orchestrator.ItemIDSearchAttribute.GetName())
+	execution.Type = keyword(orchestrator.ItemTypeSearchAttribute.GetName())
+	execution.ItemStatus = keyword(orchestrator.ItemStatusSearchAttribute.GetName())
+	execution.Decision = keyword(orchestrator.OrchestratorDecisionSearchAttribute.GetName())
+	return execution
+}
+
+// RegisterSearchAttributes adds the search attributes of the item workflows to namespace, unless they exist.
+// This requires access to the operator service, e.g. of a self-hosted or development server.
+func (c *Client) RegisterSearchAttributes(ctx context.Context, namespace string) error {
+	existing, err := c.Temporal.OperatorService().ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{Namespace: namespace})
+	if err != nil {
+		return fmt.Errorf("unable to list search attributes: %w", err)
+	}
+	missing := make(map[string]enumspb.IndexedValueType)
+	for _, key := range orchestrator.SearchAttributes {
+		valueType, exists := existing.GetCustomAttributes()[key.GetName()]
+		if !exists {
+			missing[key.GetName()] = key.GetValueType()
+			continue
+		}
+		if valueType != key.GetValueType() {
+			return fmt.Errorf("search attribute %s has type %s, expected %s", key.GetName(), valueType, key.GetValueType())
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	_, err = c.Temporal.OperatorService().AddSearchAttributes(ctx, &operatorservice.AddSearchAttributesRequest{
+		Namespace:        namespace,
+		SearchAttributes: missing,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to add search attributes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package orchestratorclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SearchFilter_VisibilityQuery(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, "WorkflowType IN ('ItemWorkflowA', 'ItemWorkflowB')", SearchFilter{}.VisibilityQuery(now))
+
+	filter := SearchFilter{
+		ItemIDs:   []string{"it'em-1"},
+		Types:     []string{"B"},
+		Statuses:  []string{"failed", "Cancelled"},
+		Decisions: []string{"denied"},
+		Since:     7 * 24 * time.Hour,
+		Query:     "ExecutionStatus = 'Failed'",
+	}
+	require.NoError(t, filter.Validate())
+	require.Equal(t, "WorkflowType IN ('ItemWorkflowA', 'ItemWorkflowB')"+
+		" AND ItemID = 'it\\'em-1'"+
+		" AND ItemType = 'b'"+
+		" AND ItemStatus IN ('Failed', 'Cancelled')"+
+		" AND OrchestratorDecision = 'Denied'"+
+		" AND StartTime > '2026-10-11T12:00:00Z'"+
+		" AND (ExecutionStatus = 'Failed')", filter.VisibilityQuery(now))
+
+	require.ErrorContains(t, (&SearchFilter{Types: []string{"c"}}).Validate(), "unknown item type")
+	require.ErrorContains(t, (&SearchFilter{Statuses: []string{"Done"}}).Validate(), "unknown item status")
+	require.ErrorContains(t, (&SearchFilter{Decisions: []string{"Maybe"}}).Validate(), "unknown decision")
+}
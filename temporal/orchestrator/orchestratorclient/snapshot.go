@@ -0,0 +1,105 @@
+package orchestratorclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"my-samples-go/temporal/orchestrator"
+	"sort"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// ErrOrchestratorRunning is returned when a snapshot is restored while an orchestrator is running.
+var ErrOrchestratorRunning = errors.New("orchestrator is already running")
+
+// ExportState snapshots the state of the running orchestrator through its queries.
+func (c *Client) ExportState(ctx context.Context) (*orchestrator.Snapshot, error) {
+	state, err := c.QueryOrchestrator(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return orchestrator.NewSnapshot(orchestrator.OrchestratorState{
+		SignalsHandled:    state.SignalsHandled,
+		OrchestratedItems: state.OrchestratedItems,
+		QueuedItems:       state.QueuedItems,
+		Config:            state.Config,
+		PrunedItems:       state.PrunedItems,
+	}, time.Now().UTC()), nil
+}
+
+// RestoreOptions control how a snapshot is restored.
+type RestoreOptions struct {
+	Terminate bool // terminate a running orchestrator and replace it, otherwise ErrOrchestratorRunning is returned
+	Force     bool // skip checking the item workflows of the registered items
+}
+
+// RestoreState starts a new orchestrator seeded with the snapshot's state, after validating the snapshot.
+// Unless forced, every registered item's workflow must still be running and must not have been launched by
+// the previous orchestrator, which alone awaited it; otherwise the item would never release its processing slot.
+func (c *Client) RestoreState(ctx context.Context, snapshot *orchestrator.Snapshot, opts RestoreOptions) (client.WorkflowRun, error) {
+	if err := snapshot.Validate(); err != nil {
+		return nil, err
+	}
+	if !opts.Force {
+		if err := c.checkItemWorkflows(ctx, snapshot.State.OrchestratedItems); err != nil {
+			return nil, err
+		}
+	}
+
+	options := client.StartWorkflowOptions{
+		ID:                                       orchestrator.OrchestratorWorkflowID,
+		TaskQueue:                                c.TaskQueue,
+		WorkflowIDConflictPolicy:                 enumspb.WORKFLOW_ID_CONFLICT_POLICY_FAIL,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
+	}
+	if opts.Terminate {
+		// Terminating and starting in one request leaves no gap for a signal to start an empty orchestrator.
+		options.WorkflowIDConflictPolicy = enumspb.WORKFLOW_ID_CONFLICT_POLICY_TERMINATE_EXISTING
+	}
+	run, err := c.Temporal.ExecuteWorkflow(ctx, options, orchestrator.OrchestratorWorkflowName, snapshot.State)
+	var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+	if errors.As(err, &alreadyStarted) {
+		return nil, fmt.Errorf("%w, terminate it to replace it", ErrOrchestratorRunning)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to start orchestrator workflow: %w", err)
+	}
+	return run, nil
+}
+
+// checkItemWorkflows reports the registered items whose workflows would not deregister from a new orchestrator.
+func (c *Client) checkItemWorkflows(ctx context.Context, items map[string]orchestrator.OrchestratedItem) error {
+	ids := make([]string, 0, len(items))
+	for id, item := range items {
+		if !item.Deregistered {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var problems []error
+	for _, id := range ids {
+		item := items[id]
+		resp, err := c.Temporal.DescribeWorkflowExecution(ctx, item.ItemWorkflowID, item.ItemWorkflowRunID)
+		var notFound *serviceerror.NotFound
+		switch {
+		case errors.As(err, &notFound):
+			problems = append(problems, fmt.Errorf("item %s: workflow %s not found", id, item.ItemWorkflowID))
+		case err != nil:
+			return fmt.Errorf("unable to describe the workflow of item %s: %w", id, err)
+		case resp.GetWorkflowExecutionInfo().GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
+			problems = append(problems, fmt.Errorf("item %s: workflow %s is %s", id, item.ItemWorkflowID, resp.GetWorkflowExecutionInfo().GetStatus()))
+		case resp.GetWorkflowExecutionInfo().GetParentExecution().GetWorkflowId() == orchestrator.OrchestratorWorkflowID:
+			problems = append(problems, fmt.Errorf("item %s: workflow %s was launched by the previous orchestrator", id, item.ItemWorkflowID))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("registered items cannot be restored, wait for them to finish (e.g. pause the orchestrator before exporting) or force the restore: %w",
+			errors.Join(problems...))
+	}
+	return nil
+}
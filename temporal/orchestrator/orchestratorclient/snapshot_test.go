@@ -0,0 +1,71 @@
+package orchestratorclient
+
+import (
+	"context"
+	"my-samples-go/temporal/orchestrator"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+)
+
+func expectDescribe(c *mocks.Client, workflowID string, info *workflowpb.WorkflowExecutionInfo, err error) {
+	var resp *workflowservice.DescribeWorkflowExecutionResponse
+	if info != nil {
+		resp = &workflowservice.DescribeWorkflowExecutionResponse{WorkflowExecutionInfo: info}
+	}
+	c.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").Return(resp, err).Once()
+}
+
+func Test_RestoreState(t *testing.T) {
+	c := mocks.NewClient(t)
+	oc := New(c, "")
+	snapshot := orchestrator.NewSnapshot(orchestrator.OrchestratorState{
+		SignalsHandled: 7,
+		OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+			"item-1": {ID: "item-1", ItemWorkflowID: "item_item-1_x", InProgress: true},
+			"item-2": {ID: "item-2", ItemWorkflowID: "item_item-2_x"},
+			"item-3": {ID: "item-3", ItemWorkflowID: "item_item-3_x", Deregistered: true},
+		},
+	}, time.Now())
+
+	// The item workflows of the registered items must be running and not children of the orchestrator.
+	expectDescribe(c, "item_item-1_x", &workflowpb.WorkflowExecutionInfo{
+		Status:          enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		ParentExecution: &commonpb.WorkflowExecution{WorkflowId: orchestrator.OrchestratorWorkflowID},
+	}, nil)
+	expectDescribe(c, "item_item-2_x", nil, serviceerror.NewNotFound("workflow not found"))
+	_, err := oc.RestoreState(context.Background(), snapshot, RestoreOptions{})
+	require.ErrorContains(t, err, "item item-1: workflow item_item-1_x was launched by the previous orchestrator")
+	require.ErrorContains(t, err, "item item-2: workflow item_item-2_x not found")
+
+	running := &workflowpb.WorkflowExecutionInfo{Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING}
+	expectDescribe(c, "item_item-1_x", running, nil)
+	expectDescribe(c, "item_item-2_x", running, nil)
+	c.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+		return options.ID == orchestrator.OrchestratorWorkflowID && options.WorkflowIDConflictPolicy == enumspb.WORKFLOW_ID_CONFLICT_POLICY_FAIL
+	}), orchestrator.OrchestratorWorkflowName, snapshot.State).Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("already started", "", "")).Once()
+	_, err = oc.RestoreState(context.Background(), snapshot, RestoreOptions{})
+	require.ErrorIs(t, err, ErrOrchestratorRunning)
+
+	// Forced, the item workflows are not checked; terminating replaces the running orchestrator.
+	run := mocks.NewWorkflowRun(t)
+	c.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+		return options.WorkflowIDConflictPolicy == enumspb.WORKFLOW_ID_CONFLICT_POLICY_TERMINATE_EXISTING
+	}), orchestrator.OrchestratorWorkflowName, snapshot.State).Return(run, nil).Once()
+	restored, err := oc.RestoreState(context.Background(), snapshot, RestoreOptions{Terminate: true, Force: true})
+	require.NoError(t, err)
+	require.Equal(t, run, restored)
+
+	snapshot.Version = 0
+	_, err = oc.RestoreState(context.Background(), snapshot, RestoreOptions{Force: true})
+	require.ErrorContains(t, err, "unsupported snapshot version")
+}
@@ -0,0 +1,201 @@
+package orchestratorclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"my-samples-go/temporal/orchestrator"
+	"strings"
+	"time"
+)
+
+// Transition is a change of an item's state or status observed between two refreshes.
+type Transition struct {
+	At         time.Time
+	ID         string
+	FromState  string // empty if the item is new
+	ToState    string
+	FromStatus string
+	ToStatus   string
+}
+
+func (t Transition) String() string {
+	if t.FromState == "" {
+		return fmt.Sprintf("%s  %s: new, %s (%s)", t.At.Format(time.TimeOnly), t.ID, t.ToState, t.ToStatus)
+	}
+	return fmt.Sprintf("%s  %s: %s (%s) -> %s (%s)", t.At.Format(time.TimeOnly), t.ID, t.FromState, t.FromStatus, t.ToState, t.ToStatus)
+}
+
+// DiffItems returns the transitions from prev to cur, in the order of cur.
+func DiffItems(prev []ItemRow, cur []ItemRow, at time.Time) []Transition {
+	before := make(map[string]ItemRow, len(prev))
+	for _, row := range prev {
+		before[row.ID] = row
+	}
+	var transitions []Transition
+	for _, row := range cur {
+		old, existed := before[row.ID]
+		if existed && old.State == row.State && old.Status == row.Status {
+			continue
+		}
+		transitions = append(transitions, Transition{
+			At:         at,
+			ID:         row.ID,
+			FromState:  old.State,
+			ToState:    row.State,
+			FromStatus: old.Status,
+			ToStatus:   row.Status,
+		})
+	}
+	return transitions
+}
+
+// ANSI escape sequences used by the watch view
+const (
+	ansiClearScreen = "\033[H\033[2J"
+	ansiHighlight   = "\033[1;33m"
+	ansiError       = "\033[31m"
+	ansiReset       = "\033[0m"
+)
+
+// Watcher periodically queries the orchestrator and renders its state as a continuously updating terminal view.
+type Watcher struct {
+	Client         *Client
+	Filter         ItemFilter
+	Interval       time.Duration
+	Out            io.Writer
+	Color          bool          // highlight changes with ANSI colors
+	MaxTransitions int           // number of recent transitions shown, 10 if zero
+	QueryTimeout   time.Duration // 5 seconds if zero, queries block while no worker is polling
+
+	rows        []ItemRow
+	transitions []Transition
+	signals     int
+	refreshedAt time.Time
+	refreshed   bool
+}
+
+// Run refreshes the view every Interval until ctx is done. Query errors are shown in the view,
+// e.g. while the orchestrator is not running, and do not stop the watcher.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		queryCtx, cancel := context.WithTimeout(ctx, w.queryTimeout())
+		state, err := w.Client.QueryState(queryCtx, w.Filter.QueryRequest())
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+		if _, werr := io.WriteString(w.Out, ansiClearScreen+w.Refresh(state, err, time.Now())); werr != nil {
+			return werr
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh records a query result and returns the rendered view.
+func (w *Watcher) Refresh(state *orchestrator.QueryResponse, queryErr error, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Orchestrator %s   every %s   %s\n\n", orchestrator.OrchestratorWorkflowID, w.Interval, now.Format(time.TimeOnly))
+	if queryErr != nil {
+		fmt.Fprintf(&b, "%s\n\n", w.colorize(ansiError, "Query failed: "+queryErr.Error()))
+		w.renderTransitions(&b)
+		return b.String()
+	}
+
+	rows := ItemRows(state, w.Filter)
+	changed := make(map[string]bool)
+	if w.refreshed {
+		newTransitions := DiffItems(w.rows, rows, now)
+		for _, t := range newTransitions {
+			changed[t.ID] = true
+		}
+		w.transitions = append(w.transitions, newTransitions...)
+		if max := w.maxTransitions(); len(w.transitions) > max {
+			w.transitions = w.transitions[len(w.transitions)-max:]
+		}
+	}
+
+	throughput := "-"
+	if w.refreshed && now.After(w.refreshedAt) && state.SignalsHandled >= w.signals {
+		throughput = fmt.Sprintf("%.2f/s", float64(state.SignalsHandled-w.signals)/now.Sub(w.refreshedAt).Seconds())
+	}
+	fmt.Fprintf(&b, "Signals handled: %d (%s)   Paused: %t   Draining: %t\n\n", state.SignalsHandled, throughput, state.Config.Paused, state.Config.Draining)
+
+	var inProgress, registered, queued []ItemRow
+	deregistered := 0
+	for _, row := range rows {
+		switch row.State {
+		case ItemStateInProgress:
+			inProgress = append(inProgress, row)
+		case ItemStateRegistered:
+			registered = append(registered, row)
+		case ItemStateQueued:
+			queued = append(queued, row)
+		case ItemStateDeregistered:
+			deregistered++
+		}
+	}
+	w.renderItems(&b, "In progress", inProgress, changed)
+	w.renderItems(&b, "Registered, waiting to start", registered, changed)
+	w.renderItems(&b, "Queued", queued, changed)
+	fmt.Fprintf(&b, "Deregistered: %d\n\n", deregistered)
+	w.renderTransitions(&b)
+
+	w.rows = rows
+	w.signals = state.SignalsHandled
+	w.refreshedAt = now
+	w.refreshed = true
+	return b.String()
+}
+
+func (w *Watcher) renderItems(b *strings.Builder, title string, rows []ItemRow, changed map[string]bool) {
+	fmt.Fprintf(b, "%s (%d)\n", title, len(rows))
+	if len(rows) == 0 {
+		b.WriteString("  -\n")
+	}
+	for _, row := range rows {
+		line := fmt.Sprintf("  %-20s %-4s %-12s %s", row.ID, row.Type, row.Status, row.ItemWorkflowID)
+		if changed[row.ID] {
+			line = w.colorize(ansiHighlight, line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+}
+
+func (w *Watcher) renderTransitions(b *strings.Builder) {
+	b.WriteString("Recent transitions\n")
+	if len(w.transitions) == 0 {
+		b.WriteString("  -\n")
+	}
+	for i := len(w.transitions) - 1; i >= 0; i-- {
+		b.WriteString("  " + w.transitions[i].String() + "\n")
+	}
+}
+
+func (w *Watcher) colorize(color string, s string) string {
+	if !w.Color {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+func (w *Watcher) maxTransitions() int {
+	if w.MaxTransitions > 0 {
+		return w.MaxTransitions
+	}
+	return 10
+}
+
+func (w *Watcher) queryTimeout() time.Duration {
+	if w.QueryTimeout > 0 {
+		return w.QueryTimeout
+	}
+	return 5 * time.Second
+}
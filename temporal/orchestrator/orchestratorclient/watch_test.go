@@ -0,0 +1,60 @@
+package orchestratorclient
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DiffItems(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	prev := []ItemRow{
+		{ID: "a-1", State: ItemStateRegistered, Status: "New"},
+		{ID: "b-1", State: ItemStateInProgress, Status: "Processing"},
+	}
+	cur := []ItemRow{
+		{ID: "a-1", State: ItemStateInProgress, Status: "Processing"},
+		{ID: "b-1", State: ItemStateInProgress, Status: "Processing"},
+		{ID: "c-1", State: ItemStateQueued, Status: "New"},
+	}
+
+	transitions := DiffItems(prev, cur, now)
+	require.Len(t, transitions, 2)
+	require.Equal(t, "12:00:00  a-1: registered (New) -> in-progress (Processing)", transitions[0].String())
+	require.Equal(t, "12:00:00  c-1: new, queued (New)", transitions[1].String())
+}
+
+func Test_Watcher_Refresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	w := &Watcher{Interval: 2 * time.Second}
+
+	state := testQueryResponse()
+	view := w.Refresh(state, nil, now)
+	require.Contains(t, view, "Signals handled: 0 (-)")
+	require.Contains(t, view, "In progress (1)\n  a-1")
+	require.Contains(t, view, "Registered, waiting to start (1)\n  c-1")
+	require.Contains(t, view, "Queued (2)\n  q-2")
+	require.Contains(t, view, "Deregistered: 1")
+
+	// c-1 starts processing while a-1 finishes, 10 signals were handled in the meantime.
+	state = testQueryResponse()
+	a1, c1 := state.OrchestratedItems["a-1"], state.OrchestratedItems["c-1"]
+	a1.InProgress, a1.Deregistered = false, true
+	c1.InProgress = true
+	state.OrchestratedItems["a-1"], state.OrchestratedItems["c-1"] = a1, c1
+	state.SignalsHandled = 10
+	w.Color = true
+	view = w.Refresh(state, nil, now.Add(2*time.Second))
+	require.Contains(t, view, "Signals handled: 10 (5.00/s)")
+	require.Contains(t, view, "In progress (1)\n"+ansiHighlight+"  c-1")
+	require.Contains(t, view, "Deregistered: 2")
+	require.Contains(t, view, "a-1: in-progress (Processing) -> deregistered (Processing)")
+	require.Contains(t, view, "c-1: registered (New) -> in-progress (New)")
+
+	// Query errors keep the transitions visible.
+	view = w.Refresh(nil, errors.New("workflow not found"), now.Add(4*time.Second))
+	require.Contains(t, view, "Query failed: workflow not found")
+	require.Contains(t, view, "c-1: registered (New) -> in-progress (New)")
+}
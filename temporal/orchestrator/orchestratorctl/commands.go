@@ -0,0 +1,456 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var submitFlags struct {
+	push        bool
+	wait        bool
+	name        string
+	fieldValues map[string]func() map[string]string
+}
+
+var submitCommand = command{
+	usage: "submit [flags] <item-type> <item-id>",
+	help: "Submit an item to the orchestrator, which launches it as a child workflow when capacity allows.\n" +
+		"With -push the item workflow is started directly and registers itself with the orchestrator,\n" +
+		"without -wait its outcome can be fetched later with the result command.\n" +
+		"Item types: " + strings.Join(orchestrator.ItemTypes.Names(), ", "),
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolVar(&submitFlags.push, "push", false, "start the item workflow directly instead of submitting it to the orchestrator")
+		fs.BoolVar(&submitFlags.wait, "wait", false, "with -push, wait for the item workflow to complete and print its result")
+		fs.StringVar(&submitFlags.name, "name", "", "item name, generated from the type and ID if empty")
+		submitFlags.fieldValues = make(map[string]func() map[string]string)
+		for _, itemType := range orchestrator.ItemTypes.Types() {
+			submitFlags.fieldValues[itemType.Name] = itemType.RegisterFlags(fs)
+		}
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if len(args) != 2 {
+			return usageError("an item type and ID must be provided")
+		}
+		itemType, err := orchestrator.ItemTypes.Lookup(args[0])
+		if err != nil {
+			return usageError("%v", err)
+		}
+		item := itemType.NewItem(args[1], submitFlags.name, submitFlags.fieldValues[itemType.Name]())
+
+		if !submitFlags.push {
+			if err := c.SubmitItem(ctx, itemType, item); err != nil {
+				return fmt.Errorf("unable to submit item to orchestrator workflow: %w", err)
+			}
+			fmt.Printf("Item '%s' submitted to the orchestrator\n", item.ID())
+			return nil
+		}
+
+		we, err := c.StartItem(ctx, itemType, item)
+		if err != nil {
+			return fmt.Errorf("unable to execute workflow: %w", err)
+		}
+		fmt.Printf("Item '%s' started. WorkflowID: %s, RunID: %s\n", item.ID(), we.GetID(), we.GetRunID())
+		if !submitFlags.wait {
+			return nil
+		}
+		var result string
+		if err := we.Get(ctx, &result); err != nil {
+			return fmt.Errorf("workflow failed: %w", err)
+		}
+		fmt.Printf("Workflow for item '%s' completed with result: %s\n", item.ID(), result)
+		return nil
+	},
+}
+
+var resultFlags struct {
+	timeout time.Duration
+	runID   string
+	item    bool
+}
+
+var resultCommand = command{
+	usage: "result [flags] <workflow-id>",
+	help: "Print the result of an item workflow started earlier, e.g. with 'submit -push' or a detached starter.\n" +
+		"Without -timeout the result is only fetched, an item that has not finished exits with code " + strconv.Itoa(exitPending) + ".",
+	flags: func(fs *flag.FlagSet) {
+		fs.DurationVar(&resultFlags.timeout, "timeout", 0, "wait at most this long for the item to finish, 0 does not wait")
+		fs.StringVar(&resultFlags.runID, "run-id", "", "run ID of the workflow, the latest run if empty")
+		fs.BoolVar(&resultFlags.item, "item", false, "the argument is an item ID, its workflow is looked up in the orchestrator")
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if len(args) != 1 {
+			return usageError("a workflow ID must be provided")
+		}
+		if resultFlags.timeout < 0 {
+			return usageError("the timeout must not be negative")
+		}
+		wait := resultFlags.timeout > 0
+		if wait {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, resultFlags.timeout)
+			defer cancel()
+		}
+
+		workflowID, runID := args[0], resultFlags.runID
+		if resultFlags.item {
+			var pollInterval time.Duration
+			if wait {
+				pollInterval = 2 * time.Second
+			}
+			var err error
+			if workflowID, runID, err = c.ItemWorkflow(ctx, args[0], pollInterval); err != nil {
+				return err
+			}
+		}
+		result, err := c.ItemResult(ctx, workflowID, runID, wait)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Workflow %s completed with result: %s\n", workflowID, result)
+		return nil
+	},
+}
+
+var listFlags struct {
+	output string
+	filter orchestratorclient.ItemFilter
+}
+
+var listCommand = command{
+	usage: "list [flags]",
+	help:  "List the items tracked by the orchestrator, optionally filtered, as a table, JSON, YAML or CSV.",
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&listFlags.output, "output", orchestratorclient.FormatTable, "output format: "+strings.Join(orchestratorclient.Formats, ", "))
+		listFlags.filter.RegisterFlags(fs)
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if err := orchestratorclient.ValidateFormat(listFlags.output); err != nil {
+			return usageError("%v", err)
+		}
+		if err := listFlags.filter.Validate(); err != nil {
+			return usageError("%v", err)
+		}
+		state, err := c.QueryState(ctx, listFlags.filter.QueryRequest())
+		if err != nil {
+			return err
+		}
+		return orchestratorclient.WriteItems(os.Stdout, listFlags.output, orchestratorclient.ItemRows(state, listFlags.filter))
+	},
+}
+
+var searchFlags struct {
+	output string
+	limit  int
+	filter orchestratorclient.SearchFilter
+}
+
+var searchCommand = command{
+	usage: "search [flags]",
+	help: "List item workflows through their search attributes, also those already pruned from the orchestrator state.\n" +
+		"Example: search -type b -item-status Failed -since 168h",
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&searchFlags.output, "output", orchestratorclient.FormatTable, "output format: "+strings.Join(orchestratorclient.Formats, ", "))
+		fs.IntVar(&searchFlags.limit, "limit", 100, "maximum number of item workflows to list, 0 lists all")
+		searchFlags.filter.RegisterFlags(fs)
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if err := orchestratorclient.ValidateFormat(searchFlags.output); err != nil {
+			return usageError("%v", err)
+		}
+		if err := searchFlags.filter.Validate(); err != nil {
+			return usageError("%v", err)
+		}
+		executions, err := c.SearchItems(ctx, searchFlags.filter, searchFlags.limit)
+		if err != nil {
+			return err
+		}
+		return orchestratorclient.WriteExecutions(os.Stdout, searchFlags.output, executions)
+	},
+}
+
+var describeCommand = command{
+	usage: "describe <item-id>",
+	help:  "Describe a single item, as seen by the orchestrator and by its own item workflow.",
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if len(args) != 1 {
+			return usageError("an item ID must be provided")
+		}
+		item, queued, err := c.FindItem(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if queued != nil {
+			return printJSON(map[string]interface{}{"queued": queued})
+		}
+		itemState, err := c.DescribeItem(ctx, item.ItemWorkflowID)
+		if err != nil {
+			return err
+		}
+		return printJSON(map[string]interface{}{"orchestrator": item, "workflow": itemState})
+	},
+}
+
+var cancelCommand = command{
+	usage: "cancel <item-id>",
+	help:  "Cancel an item. Queued items are removed from the queue, running item workflows are cancelled and deregistered.",
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if len(args) != 1 {
+			return usageError("an item ID must be provided")
+		}
+		if err := c.CancelItem(ctx, args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Item '%s' cancelled\n", args[0])
+		return nil
+	},
+}
+
+var deregisterCommand = command{
+	usage: "deregister <item-id>",
+	help:  "Deregister an item on its behalf, releasing its processing slot, e.g. after its workflow was terminated.",
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if len(args) != 1 {
+			return usageError("an item ID must be provided")
+		}
+		item, _, err := c.FindItem(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if item == nil {
+			return fmt.Errorf("item %s is queued, use cancel instead", args[0])
+		}
+		if err := c.DeregisterItem(ctx, args[0], &orchestrator.CompletionReport{Result: "Deregistered", Error: "Deregistered by operator"}); err != nil {
+			return err
+		}
+		fmt.Printf("Item '%s' deregistered\n", args[0])
+		return nil
+	},
+}
+
+var drainCommand = operatorSignalCommand(orchestrator.DrainSignal, "Stop accepting new items and drop the queued ones. The orchestrator finishes once the running item is done.")
+var pauseCommand = operatorSignalCommand(orchestrator.PauseSignal, "Stop granting start-processing requests and launching queued items.")
+var resumeCommand = operatorSignalCommand(orchestrator.ResumeSignal, "Undo pause and drain.")
+
+func operatorSignalCommand(signalType orchestrator.SignalType, help string) command {
+	return command{
+		usage: string(signalType),
+		help:  help,
+		run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+			if err := c.Signal(ctx, orchestrator.Signal{Type: signalType}); err != nil {
+				return err
+			}
+			fmt.Printf("Orchestrator signalled: %s\n", signalType)
+			return nil
+		},
+	}
+}
+
+var configFlags struct {
+	idleTimeout time.Duration
+	retention   time.Duration
+}
+
+var configCommand = command{
+	usage: "config [-idle-timeout <duration>] [-retention <duration>]",
+	help:  "Show the orchestrator configuration, or change it when flags are given.",
+	flags: func(fs *flag.FlagSet) {
+		fs.DurationVar(&configFlags.idleTimeout, "idle-timeout", -1, "time without signals after which an idle orchestrator finishes, 0 restores the default")
+		fs.DurationVar(&configFlags.retention, "retention", -1, "time after which finished items are pruned from the state, 0 keeps them")
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		summary, err := c.QuerySummary(ctx)
+		if err != nil {
+			return err
+		}
+		if configFlags.idleTimeout < 0 && configFlags.retention < 0 {
+			return printJSON(summary.Config)
+		}
+
+		// The config signal sets all settings, keep the ones without a flag.
+		payload := orchestrator.ConfigPayload{IdleTimeout: summary.Config.IdleTimeout, Retention: summary.Config.Retention}
+		if configFlags.idleTimeout >= 0 {
+			payload.IdleTimeout = configFlags.idleTimeout
+		}
+		if configFlags.retention >= 0 {
+			payload.Retention = configFlags.retention
+		}
+		err = c.Signal(ctx, orchestrator.Signal{Type: orchestrator.ConfigSignal, Payload: payload})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Orchestrator idle timeout set to %s, retention to %s\n", payload.IdleTimeout, payload.Retention)
+		return nil
+	},
+}
+
+var exportFlags struct {
+	output string
+}
+
+var exportCommand = command{
+	usage: "export [flags]",
+	help: "Snapshot the orchestrator state into a versioned JSON file, for backup or to restore it elsewhere.\n" +
+		"Pause the orchestrator and wait for the item in progress to finish first, so the snapshot can be restored.",
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&exportFlags.output, "o", "-", "snapshot file, - for stdout")
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		snapshot, err := c.ExportState(ctx)
+		if err != nil {
+			return err
+		}
+		if exportFlags.output == "-" {
+			return orchestrator.WriteSnapshot(os.Stdout, snapshot)
+		}
+		f, err := os.Create(exportFlags.output)
+		if err != nil {
+			return err
+		}
+		if err := orchestrator.WriteSnapshot(f, snapshot); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d items and %d queued items to %s\n",
+			len(snapshot.State.OrchestratedItems), len(snapshot.State.QueuedItems), exportFlags.output)
+		return nil
+	},
+}
+
+var restoreFlags struct {
+	terminate bool
+	force     bool
+}
+
+var restoreCommand = command{
+	usage: "restore [flags] <snapshot-file>",
+	help: "Start a new orchestrator seeded with an exported state, e.g. after a disaster or in another namespace (-namespace).\n" +
+		"The snapshot is validated first, and the workflows of its registered items must still be running.",
+	flags: func(fs *flag.FlagSet) {
+		fs.BoolVar(&restoreFlags.terminate, "terminate", false, "terminate a running orchestrator and replace it")
+		fs.BoolVar(&restoreFlags.force, "force", false, "do not check the workflows of the registered items")
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if len(args) != 1 {
+			return usageError("a snapshot file must be provided")
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		snapshot, err := orchestrator.ReadSnapshot(f)
+		if err != nil {
+			return err
+		}
+		run, err := c.RestoreState(ctx, snapshot, orchestratorclient.RestoreOptions{Terminate: restoreFlags.terminate, Force: restoreFlags.force})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Orchestrator restored from %s (snapshot of %s): WorkflowID %s, RunID %s\n",
+			args[0], snapshot.CreatedAt.Format(time.RFC3339), run.GetID(), run.GetRunID())
+		if snapshot.State.Config.Paused {
+			fmt.Println("The orchestrator was paused when exported, resume it to continue processing.")
+		}
+		return nil
+	},
+}
+
+var scheduleFlags struct {
+	file   string
+	output string
+	prune  bool
+	dryRun bool
+}
+
+var scheduleCommand = command{
+	usage: "schedule [flags] <list|create|update|delete|sync> [schedule-id]",
+	help: "Manage the Temporal Schedules that periodically submit items to the orchestrator (pull model).\n" +
+		"The schedules are defined in a YAML file, see orchestrator/schedules.yaml. create and update apply the definition\n" +
+		"of a single schedule, sync creates and updates all defined schedules and with -prune deletes the others.",
+	flags: func(fs *flag.FlagSet) {
+		fs.StringVar(&scheduleFlags.file, "file", "orchestrator/schedules.yaml", "schedule definitions file")
+		fs.StringVar(&scheduleFlags.output, "output", orchestratorclient.FormatTable, "list output format: "+strings.Join(orchestratorclient.Formats, ", "))
+		fs.BoolVar(&scheduleFlags.prune, "prune", false, "sync: delete item schedules that are not defined")
+		fs.BoolVar(&scheduleFlags.dryRun, "dry-run", false, "sync: only print the changes")
+	},
+	run: func(ctx context.Context, c *orchestratorclient.Client, args []string) error {
+		if len(args) == 0 {
+			return usageError("an action must be provided")
+		}
+		action, args := args[0], args[1:]
+		switch action {
+		case "list":
+			if err := orchestratorclient.ValidateFormat(scheduleFlags.output); err != nil {
+				return usageError("%v", err)
+			}
+			schedules, err := c.ListSchedules(ctx)
+			if err != nil {
+				return err
+			}
+			return orchestratorclient.WriteSchedules(os.Stdout, scheduleFlags.output, schedules)
+		case "delete":
+			if len(args) != 1 {
+				return usageError("a schedule ID must be provided")
+			}
+			if err := c.DeleteSchedule(ctx, args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Schedule '%s' deleted\n", args[0])
+			return nil
+		case "create", "update":
+			if len(args) != 1 {
+				return usageError("a schedule ID must be provided")
+			}
+			defs, err := orchestratorclient.LoadScheduleFile(scheduleFlags.file)
+			if err != nil {
+				return err
+			}
+			def, err := orchestratorclient.FindSchedule(defs, args[0])
+			if err != nil {
+				return err
+			}
+			if action == "create" {
+				err = c.CreateSchedule(ctx, def)
+			} else {
+				err = c.UpdateSchedule(ctx, def)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Schedule '%s' %sd\n", def.ID, action)
+			return nil
+		case "sync":
+			if len(args) != 0 {
+				return usageError("sync takes no arguments")
+			}
+			defs, err := orchestratorclient.LoadScheduleFile(scheduleFlags.file)
+			if err != nil {
+				return err
+			}
+			changes, err := c.SyncSchedules(ctx, defs, orchestratorclient.SyncOptions{Prune: scheduleFlags.prune, DryRun: scheduleFlags.dryRun})
+			for _, change := range changes {
+				fmt.Printf("%-10s %s\n", change.Change, change.ID)
+			}
+			if err == nil && scheduleFlags.dryRun {
+				fmt.Println("Dry run, no schedules were changed")
+			}
+			return err
+		default:
+			return usageError("unknown schedule action %q", action)
+		}
+	},
+}
+
+func printJSON(v interface{}) error {
+	return orchestratorclient.WriteValue(os.Stdout, orchestratorclient.FormatJSON, v)
+}
@@ -0,0 +1,161 @@
+// orchestratorctl is the command line tool to start, inspect and manage the orchestrator sample.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"os"
+	"sort"
+	"strings"
+
+	"go.temporal.io/api/serviceerror"
+)
+
+// Exit codes
+const (
+	exitOK       = 0
+	exitError    = 1 // the operation failed
+	exitUsage    = 2 // invalid command line
+	exitNotFound = 3 // the item or workflow does not exist
+	exitPending  = 4 // the item has not finished, or not within the timeout
+)
+
+// errUsage marks errors caused by an invalid command line.
+var errUsage = errors.New("invalid usage")
+
+type command struct {
+	usage string
+	help  string
+	run   func(ctx context.Context, c *orchestratorclient.Client, args []string) error
+	flags func(fs *flag.FlagSet) // optional, registers command specific flags
+}
+
+var commands = map[string]command{
+	"submit":     submitCommand,
+	"list":       listCommand,
+	"search":     searchCommand,
+	"describe":   describeCommand,
+	"cancel":     cancelCommand,
+	"deregister": deregisterCommand,
+	"drain":      drainCommand,
+	"pause":      pauseCommand,
+	"resume":     resumeCommand,
+	"config":     configCommand,
+	"result":     resultCommand,
+	"export":     exportCommand,
+	"restore":    restoreCommand,
+	"schedule":   scheduleCommand,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		printUsage()
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+
+	name := args[0]
+	cmd, exists := commands[name]
+	if !exists {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage()
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: orchestratorctl %s\n\n%s\n\nFlags:\n", cmd.usage, cmd.help)
+		fs.PrintDefaults()
+	}
+	// The connection flags are shared by all commands.
+	cfg := config.New(orchestrator.TaskQueueName)
+	// Keep stdout clean for the command output, SDK warnings go to stderr.
+	cfg.LogLevel = "warn"
+	cfg.RegisterFlags(fs)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if err := cfg.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid configuration:", err)
+		return exitUsage
+	}
+	shutdownTracing, err := cfg.StartTracing(context.Background(), "orchestratorctl")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid configuration:", err)
+		return exitUsage
+	}
+	defer shutdownTracing(context.Background())
+
+	c, err := cfg.Dial()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to create Temporal client:", err)
+		return exitError
+	}
+	defer c.Close()
+
+	oc := orchestratorclient.New(c, cfg.TaskQueue)
+	if oc.DataConverter, err = cfg.DataConverter(); err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid configuration:", err)
+		return exitUsage
+	}
+	err = cmd.run(context.Background(), oc, fs.Args())
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintln(os.Stderr, err)
+		fs.Usage()
+		return exitUsage
+	case errors.Is(err, orchestratorclient.ErrItemRunning):
+		fmt.Fprintln(os.Stderr, err)
+		return exitPending
+	case isNotFound(err):
+		fmt.Fprintln(os.Stderr, err)
+		return exitNotFound
+	default:
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+}
+
+func printUsage() {
+	out := os.Stderr
+	fmt.Fprintf(out, "Usage: orchestratorctl <command> [flags] [args]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-11s %s\n", name, strings.SplitN(commands[name].help, "\n", 2)[0])
+	}
+	fmt.Fprintf(out, "\nRun 'orchestratorctl <command> -h' for the flags of a command.\n")
+	fmt.Fprintf(out, "\nExit codes: %d success, %d failure, %d usage error, %d not found, %d not finished.\n",
+		exitOK, exitError, exitUsage, exitNotFound, exitPending)
+}
+
+func usageError(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{errUsage}, args...)...)
+}
+
+func isNotFound(err error) bool {
+	var notFound *serviceerror.NotFound
+	return errors.Is(err, orchestratorclient.ErrItemNotFound) || errors.As(err, &notFound)
+}
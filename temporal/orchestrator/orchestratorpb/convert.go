@@ -0,0 +1,315 @@
+package orchestratorpb
+
+import (
+	"encoding/json"
+	"fmt"
+	"my-samples-go/temporal/orchestrator"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var signalTypes = map[SignalType]orchestrator.SignalType{
+	SignalType_SIGNAL_TYPE_REGISTER:         orchestrator.RegisterSignal,
+	SignalType_SIGNAL_TYPE_DEREGISTER:       orchestrator.DeregisterSignal,
+	SignalType_SIGNAL_TYPE_START_PROCESSING: orchestrator.StartProcessingSignal,
+	SignalType_SIGNAL_TYPE_STOP_PROCESSING:  orchestrator.StopProcessingSignal,
+	SignalType_SIGNAL_TYPE_UPDATE:           orchestrator.UpdateSignal,
+	SignalType_SIGNAL_TYPE_SUBMIT:           orchestrator.SubmitSignal,
+	SignalType_SIGNAL_TYPE_CANCEL:           orchestrator.CancelSignal,
+	SignalType_SIGNAL_TYPE_PAUSE:            orchestrator.PauseSignal,
+	SignalType_SIGNAL_TYPE_RESUME:           orchestrator.ResumeSignal,
+	SignalType_SIGNAL_TYPE_DRAIN:            orchestrator.DrainSignal,
+	SignalType_SIGNAL_TYPE_CONFIG:           orchestrator.ConfigSignal,
+	SignalType_SIGNAL_TYPE_PING:             orchestrator.PingSignal,
+}
+
+// SignalFromProto converts a signal to the Go type the orchestrator workflow receives.
+func SignalFromProto(sig *Signal) (orchestrator.Signal, error) {
+	signalType, known := signalTypes[sig.GetType()]
+	if !known {
+		return orchestrator.Signal{}, fmt.Errorf("unknown signal type %s", sig.GetType())
+	}
+	result := orchestrator.Signal{Type: signalType}
+	switch p := sig.GetPayload().(type) {
+	case nil:
+	case *Signal_Register:
+		result.Payload = orchestrator.RegisterPayload{
+			ID:                p.Register.GetId(),
+			ItemWorkflowID:    p.Register.GetItemWorkflowId(),
+			ItemWorkflowRunID: p.Register.GetItemWorkflowRunId(),
+			WorkflowName:      p.Register.GetWorkflowName(),
+			Item:              itemFromProto(p.Register.GetItem()),
+		}
+	case *Signal_Deregister:
+		result.Payload = orchestrator.DeregisterPayload{
+			ID:     p.Deregister.GetId(),
+			Report: CompletionReportFromProto(p.Deregister.GetReport()),
+		}
+	case *Signal_StartProcessing:
+		result.Payload = orchestrator.StartProcessingPayload{ID: p.StartProcessing.GetId()}
+	case *Signal_StopProcessing:
+		result.Payload = orchestrator.StopProcessingPayload{ID: p.StopProcessing.GetId()}
+	case *Signal_Update:
+		result.Payload = orchestrator.UpdatePayload{ID: p.Update.GetId(), Item: itemFromProto(p.Update.GetItem())}
+	case *Signal_Submit:
+		result.Payload = orchestrator.SubmitPayload{
+			ID:           p.Submit.GetId(),
+			WorkflowName: p.Submit.GetWorkflowName(),
+			Item:         itemFromProto(p.Submit.GetItem()),
+		}
+	case *Signal_Cancel:
+		result.Payload = orchestrator.CancelPayload{ID: p.Cancel.GetId()}
+	case *Signal_Config:
+		result.Payload = orchestrator.ConfigPayload{
+			IdleTimeout: time.Duration(p.Config.GetIdleTimeout()),
+			Retention:   time.Duration(p.Config.GetRetention()),
+		}
+	default:
+		return orchestrator.Signal{}, fmt.Errorf("unsupported payload %T", p)
+	}
+	return result, nil
+}
+
+// SignalToProto converts a signal of the Go type. Its payload must be one of the payload types of the orchestrator
+// package, or be convertible into the one of its type.
+func SignalToProto(sig orchestrator.Signal) (*Signal, error) {
+	result := &Signal{}
+	for protoType, signalType := range signalTypes {
+		if signalType == sig.Type {
+			result.Type = protoType
+		}
+	}
+	if result.Type == SignalType_SIGNAL_TYPE_UNSPECIFIED {
+		return nil, fmt.Errorf("unknown signal type %q", sig.Type)
+	}
+	if sig.Payload == nil {
+		return result, nil
+	}
+
+	var err error
+	switch sig.Type {
+	case orchestrator.RegisterSignal:
+		var p orchestrator.RegisterPayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			register := &RegisterPayload{Id: p.ID, ItemWorkflowId: p.ItemWorkflowID, ItemWorkflowRunId: p.ItemWorkflowRunID, WorkflowName: p.WorkflowName}
+			register.Item, err = itemToProto(p.Item)
+			result.Payload = &Signal_Register{Register: register}
+		}
+	case orchestrator.DeregisterSignal:
+		var p orchestrator.DeregisterPayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			result.Payload = &Signal_Deregister{Deregister: &DeregisterPayload{Id: p.ID, Report: CompletionReportToProto(p.Report)}}
+		}
+	case orchestrator.StartProcessingSignal:
+		var p orchestrator.StartProcessingPayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			result.Payload = &Signal_StartProcessing{StartProcessing: &StartProcessingPayload{Id: p.ID}}
+		}
+	case orchestrator.StopProcessingSignal:
+		var p orchestrator.StopProcessingPayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			result.Payload = &Signal_StopProcessing{StopProcessing: &StopProcessingPayload{Id: p.ID}}
+		}
+	case orchestrator.UpdateSignal:
+		var p orchestrator.UpdatePayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			update := &UpdatePayload{Id: p.ID}
+			update.Item, err = itemToProto(p.Item)
+			result.Payload = &Signal_Update{Update: update}
+		}
+	case orchestrator.SubmitSignal:
+		var p orchestrator.SubmitPayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			submit := &SubmitPayload{Id: p.ID, WorkflowName: p.WorkflowName}
+			submit.Item, err = itemToProto(p.Item)
+			result.Payload = &Signal_Submit{Submit: submit}
+		}
+	case orchestrator.CancelSignal:
+		var p orchestrator.CancelPayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			result.Payload = &Signal_Cancel{Cancel: &CancelPayload{Id: p.ID}}
+		}
+	case orchestrator.ConfigSignal:
+		var p orchestrator.ConfigPayload
+		if err = orchestrator.ConvertPayload(sig.Payload, &p); err == nil {
+			result.Payload = &Signal_Config{Config: &ConfigPayload{IdleTimeout: int64(p.IdleTimeout), Retention: int64(p.Retention)}}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", sig.Type, err)
+	}
+	return result, nil
+}
+
+// ItemInstructionSignalFromProto converts an instruction to the Go type the item workflows receive.
+func ItemInstructionSignalFromProto(sig *ItemInstructionSignal) orchestrator.ItemInstructionSignal {
+	return orchestrator.ItemInstructionSignal{ID: sig.GetId(), Proceed: sig.GetProceed(), Reason: sig.GetReason()}
+}
+
+// CompletionReportFromProto converts a completion report, nil stays nil.
+func CompletionReportFromProto(report *CompletionReport) *orchestrator.CompletionReport {
+	if report == nil {
+		return nil
+	}
+	return &orchestrator.CompletionReport{
+		Result:     report.GetResult(),
+		Error:      report.GetError(),
+		StartedAt:  timeFromProto(report.GetStartedAt()),
+		FinishedAt: timeFromProto(report.GetFinishedAt()),
+		Duration:   time.Duration(report.GetDuration()),
+	}
+}
+
+// CompletionReportToProto converts a completion report, nil stays nil.
+func CompletionReportToProto(report *orchestrator.CompletionReport) *CompletionReport {
+	if report == nil {
+		return nil
+	}
+	return &CompletionReport{
+		Result:     report.Result,
+		Error:      report.Error,
+		StartedAt:  timestamppb.New(report.StartedAt),
+		FinishedAt: timestamppb.New(report.FinishedAt),
+		Duration:   int64(report.Duration),
+	}
+}
+
+// OrchestratedItemToProto converts an orchestrated item. Its payload must be representable as JSON.
+func OrchestratedItemToProto(item orchestrator.OrchestratedItem) (*OrchestratedItem, error) {
+	payload, err := valueToProto(item.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload of item %s: %w", item.ID, err)
+	}
+	return &OrchestratedItem{
+		Id:                item.ID,
+		ItemWorkflowId:    item.ItemWorkflowID,
+		ItemWorkflowRunId: item.ItemWorkflowRunID,
+		WorkflowName:      item.WorkflowName,
+		RegisteredAt:      timestamppb.New(item.RegisteredAt),
+		InProgress:        item.InProgress,
+		Deregistered:      item.Deregistered,
+		Payload:           payload,
+		Completion:        CompletionReportToProto(item.Completion),
+	}, nil
+}
+
+// OrchestratedItemFromProto converts an orchestrated item, its payload is decoded as generic JSON.
+func OrchestratedItemFromProto(item *OrchestratedItem) orchestrator.OrchestratedItem {
+	return orchestrator.OrchestratedItem{
+		ID:                item.GetId(),
+		ItemWorkflowID:    item.GetItemWorkflowId(),
+		ItemWorkflowRunID: item.GetItemWorkflowRunId(),
+		WorkflowName:      item.GetWorkflowName(),
+		RegisteredAt:      timeFromProto(item.GetRegisteredAt()),
+		InProgress:        item.GetInProgress(),
+		Deregistered:      item.GetDeregistered(),
+		Payload:           valueFromProto(item.GetPayload()),
+		Completion:        CompletionReportFromProto(item.GetCompletion()),
+	}
+}
+
+// QueryResponseToProto converts the result of the orchestrator query. The item payloads must be representable
+// as JSON.
+func QueryResponseToProto(resp orchestrator.QueryResponse) (*QueryResponse, error) {
+	result := &QueryResponse{
+		TotalItems:        int32(resp.TotalItems),
+		OrchestratedItems: make(map[string]*OrchestratedItem, len(resp.OrchestratedItems)),
+		SignalsHandled:    int32(resp.SignalsHandled),
+		Config: &OrchestratorConfig{
+			Paused:      resp.Config.Paused,
+			Draining:    resp.Config.Draining,
+			IdleTimeout: int64(resp.Config.IdleTimeout),
+			Retention:   int64(resp.Config.Retention),
+		},
+	}
+	for id, item := range resp.OrchestratedItems {
+		converted, err := OrchestratedItemToProto(item)
+		if err != nil {
+			return nil, err
+		}
+		result.OrchestratedItems[id] = converted
+	}
+	for _, item := range resp.QueuedItems {
+		payload, err := valueToProto(item.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload of queued item %s: %w", item.ID, err)
+		}
+		result.QueuedItems = append(result.QueuedItems, &QueuedItem{Id: item.ID, WorkflowName: item.WorkflowName, Payload: payload})
+	}
+	return result, nil
+}
+
+// QueryResponseFromProto converts the result of the orchestrator query, the item payloads are decoded as
+// generic JSON.
+func QueryResponseFromProto(resp *QueryResponse) orchestrator.QueryResponse {
+	result := orchestrator.QueryResponse{
+		TotalItems:        int(resp.GetTotalItems()),
+		OrchestratedItems: make(map[string]orchestrator.OrchestratedItem, len(resp.GetOrchestratedItems())),
+		SignalsHandled:    int(resp.GetSignalsHandled()),
+		Config: orchestrator.OrchestratorConfig{
+			Paused:      resp.GetConfig().GetPaused(),
+			Draining:    resp.GetConfig().GetDraining(),
+			IdleTimeout: time.Duration(resp.GetConfig().GetIdleTimeout()),
+			Retention:   time.Duration(resp.GetConfig().GetRetention()),
+		},
+	}
+	for id, item := range resp.GetOrchestratedItems() {
+		result.OrchestratedItems[id] = OrchestratedItemFromProto(item)
+	}
+	for _, item := range resp.GetQueuedItems() {
+		result.QueuedItems = append(result.QueuedItems, orchestrator.QueuedItem{
+			ID:           item.GetId(),
+			WorkflowName: item.GetWorkflowName(),
+			Payload:      valueFromProto(item.GetPayload()),
+		})
+	}
+	return result
+}
+
+// itemToProto converts an item by its JSON representation, which must be an object. nil stays nil.
+func itemToProto(item interface{}) (*structpb.Struct, error) {
+	if item == nil {
+		return nil, nil
+	}
+	var fields map[string]interface{}
+	if err := orchestrator.ConvertPayload(item, &fields); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(fields)
+}
+
+func itemFromProto(item *structpb.Struct) interface{} {
+	if item == nil {
+		return nil
+	}
+	return item.AsMap()
+}
+
+// valueToProto converts a value by its JSON representation.
+func valueToProto(value interface{}) (*structpb.Value, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(generic)
+}
+
+func valueFromProto(value *structpb.Value) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value.AsInterface()
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
@@ -0,0 +1,114 @@
+package orchestratorpb
+
+import (
+	"encoding/json"
+	"my-samples-go/temporal/orchestrator"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test_QueryResponse_ParsesFromJSON(t *testing.T) {
+	registeredAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	resp := orchestrator.QueryResponse{
+		TotalItems: 1,
+		OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+			"item-1": {
+				ID:             "item-1",
+				ItemWorkflowID: "item_item-1_1",
+				WorkflowName:   orchestrator.ItemWorkflowAName,
+				RegisteredAt:   registeredAt,
+				Deregistered:   true,
+				Payload:        orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: "item-1", Status: orchestrator.ItemStatusCompleted}, ExtraFieldA: "a"},
+				Completion:     orchestrator.NewCompletionReport("Finished Successfully", nil, registeredAt, registeredAt.Add(30*time.Second)),
+			},
+		},
+		QueuedItems:    []orchestrator.QueuedItem{{ID: "item-2", WorkflowName: orchestrator.ItemWorkflowBName, Payload: orchestrator.ItemB{ExtraFieldB: "b"}}},
+		SignalsHandled: 7,
+		Config:         orchestrator.OrchestratorConfig{Paused: true, IdleTimeout: 5 * time.Minute, Retention: time.Hour},
+	}
+
+	// Query results are plain JSON, services in other languages parse them with the proto JSON parser.
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+	var parsed QueryResponse
+	require.NoError(t, protojson.Unmarshal(data, &parsed))
+	require.Equal(t, "a", parsed.OrchestratedItems["item-1"].GetPayload().GetStructValue().GetFields()["extraFieldA"].GetStringValue())
+	require.Equal(t, int64(30*time.Second), parsed.OrchestratedItems["item-1"].GetCompletion().GetDuration())
+	require.Equal(t, int64(time.Hour), parsed.GetConfig().GetRetention())
+
+	// Both conversions keep the JSON representation.
+	converted, err := QueryResponseToProto(resp)
+	require.NoError(t, err)
+	convertedJSON, err := json.Marshal(QueryResponseFromProto(converted))
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(convertedJSON))
+	parsedJSON, err := json.Marshal(QueryResponseFromProto(&parsed))
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(parsedJSON))
+
+	instruction, err := json.Marshal(orchestrator.ItemInstructionSignal{ID: "item-1", Proceed: true, Reason: "ok"})
+	require.NoError(t, err)
+	var parsedInstruction ItemInstructionSignal
+	require.NoError(t, protojson.Unmarshal(instruction, &parsedInstruction))
+	require.True(t, parsedInstruction.GetProceed())
+}
+
+func Test_DataConverter_DecodesProtoSignals(t *testing.T) {
+	item, err := structpb.NewStruct(map[string]interface{}{"id": "item-1", "extraFieldA": "a"})
+	require.NoError(t, err)
+	sig := &Signal{
+		Type:    SignalType_SIGNAL_TYPE_SUBMIT,
+		Payload: &Signal_Submit{Submit: &SubmitPayload{Id: "item-1", WorkflowName: orchestrator.ItemWorkflowAName, Item: item}},
+	}
+
+	for _, c := range []converter.PayloadConverter{converter.NewProtoJSONPayloadConverter(), converter.NewProtoPayloadConverter()} {
+		payload, err := c.ToPayload(sig)
+		require.NoError(t, err)
+
+		var decoded orchestrator.Signal
+		require.NoError(t, DataConverter.FromPayload(payload, &decoded), c.Encoding())
+		require.Equal(t, orchestrator.SubmitSignal, decoded.Type)
+		var submit orchestrator.SubmitPayload
+		require.NoError(t, orchestrator.ConvertPayload(decoded.Payload, &submit))
+		require.Equal(t, "item-1", submit.ID)
+		var itemA orchestrator.ItemA
+		require.NoError(t, orchestrator.ConvertPayload(submit.Item, &itemA))
+		require.Equal(t, "a", itemA.ExtraFieldA)
+
+		// Proto messages are still decoded as they are.
+		var message Signal
+		require.NoError(t, DataConverter.FromPayload(payload, &message))
+		require.Equal(t, "item-1", message.GetSubmit().GetId())
+	}
+
+	// JSON signals of Go clients are decoded as before.
+	payload, err := DataConverter.ToPayload(orchestrator.Signal{Type: orchestrator.PauseSignal})
+	require.NoError(t, err)
+	var decoded orchestrator.Signal
+	require.NoError(t, DataConverter.FromPayload(payload, &decoded))
+	require.Equal(t, orchestrator.PauseSignal, decoded.Type)
+}
+
+func Test_SignalToProto(t *testing.T) {
+	sig := orchestrator.Signal{
+		Type:    orchestrator.ConfigSignal,
+		Payload: orchestrator.ConfigPayload{IdleTimeout: time.Minute, Retention: time.Hour},
+	}
+	converted, err := SignalToProto(sig)
+	require.NoError(t, err)
+	require.Equal(t, SignalType_SIGNAL_TYPE_CONFIG, converted.GetType())
+	require.Equal(t, int64(time.Hour), converted.GetConfig().GetRetention())
+	back, err := SignalFromProto(converted)
+	require.NoError(t, err)
+	require.Equal(t, sig, back)
+
+	_, err = SignalToProto(orchestrator.Signal{Type: "unknown"})
+	require.ErrorContains(t, err, "unknown signal type")
+	_, err = SignalFromProto(&Signal{})
+	require.ErrorContains(t, err, "unknown signal type")
+}
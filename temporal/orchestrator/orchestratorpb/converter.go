@@ -0,0 +1,58 @@
+package orchestratorpb
+
+import (
+	"my-samples-go/temporal/orchestrator"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/sdk/converter"
+	"google.golang.org/protobuf/proto"
+)
+
+// DataConverter is the default data converter, but decodes signals sent as protobuf payloads into the Go types
+// of the orchestrator package. The orchestrator worker uses it, so other services can signal with the messages
+// generated from orchestrator.proto.
+var DataConverter = converter.NewCompositeDataConverter(
+	converter.NewNilPayloadConverter(),
+	converter.NewByteSlicePayloadConverter(),
+	NewPayloadConverter(converter.NewProtoJSONPayloadConverter()),
+	NewPayloadConverter(converter.NewProtoPayloadConverter()),
+	converter.NewJSONPayloadConverter(),
+)
+
+// PayloadConverter wraps a protobuf payload converter: protobuf payloads are decoded into proto messages as
+// usual, and into the Go types of the orchestrator package by their protobuf counterparts.
+type PayloadConverter struct {
+	converter.PayloadConverter
+}
+
+// NewPayloadConverter wraps the protobuf payload converter c.
+func NewPayloadConverter(c converter.PayloadConverter) *PayloadConverter {
+	return &PayloadConverter{PayloadConverter: c}
+}
+
+// FromPayload decodes the payload into valuePtr.
+func (c *PayloadConverter) FromPayload(payload *commonpb.Payload, valuePtr interface{}) error {
+	switch v := valuePtr.(type) {
+	case proto.Message:
+		return c.PayloadConverter.FromPayload(payload, valuePtr)
+	case *orchestrator.Signal:
+		var sig Signal
+		if err := c.PayloadConverter.FromPayload(payload, &sig); err != nil {
+			return err
+		}
+		converted, err := SignalFromProto(&sig)
+		if err != nil {
+			return err
+		}
+		*v = converted
+		return nil
+	case *orchestrator.ItemInstructionSignal:
+		var sig ItemInstructionSignal
+		if err := c.PayloadConverter.FromPayload(payload, &sig); err != nil {
+			return err
+		}
+		*v = ItemInstructionSignalFromProto(&sig)
+		return nil
+	}
+	return c.PayloadConverter.FromPayload(payload, valuePtr)
+}
@@ -0,0 +1,5 @@
+// Package orchestratorpb holds the protobuf messages of the orchestrator sample, generated from
+// orchestrator.proto, and their conversion to and from the Go types of the orchestrator package.
+package orchestratorpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative orchestrator.proto
@@ -15,3 +15,23 @@ func ConvertPayload(payloadData interface{}, target interface{}) error {
 	}
 	return nil
 }
+
+// PayloadStatus extracts the item status from a payload decoded as generic JSON, empty if it has none.
+func PayloadStatus(payload interface{}) string {
+	var item BasicItem
+	if err := ConvertPayload(payload, &item); err != nil {
+		return ""
+	}
+	return item.GetStatus()
+}
+
+// PayloadID extracts the item ID from a signal payload decoded as generic JSON, empty if it has none.
+func PayloadID(payload interface{}) string {
+	var p struct {
+		ID string `json:"id"`
+	}
+	if err := ConvertPayload(payload, &p); err != nil {
+		return ""
+	}
+	return p.ID
+}
@@ -1,8 +1,259 @@
 package orchestrator
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
 // QueryResponse represents the response for the orchestrated items query
 type QueryResponse struct {
 	TotalItems        int                         `json:"totalItems"`
 	OrchestratedItems map[string]OrchestratedItem `json:"orchestratedItems"`
+	QueuedItems       []QueuedItem                `json:"queuedItems,omitempty"`
 	SignalsHandled    int                         `json:"signalsHandled"`
+	Config            OrchestratorConfig          `json:"config"`
+	PrunedItems       []PrunedItem                `json:"prunedItems,omitempty"` // pruned items whose claim check blobs are not deleted yet
+}
+
+// ItemQueryResponse represents the response for the item workflow query
+type ItemQueryResponse struct {
+	ID                string                 `json:"id"`
+	ItemWorkflowID    string                 `json:"itemWorkflowId"`
+	ItemWorkflowRunID string                 `json:"itemWorkflowRunId"`
+	Phase             ItemPhase              `json:"phase"`
+	Status            string                 `json:"status"`
+	LastInstruction   *ItemInstructionSignal `json:"lastInstruction,omitempty"`
+	RetryCount        int                    `json:"retryCount"`
+	StartedAt         time.Time              `json:"startedAt"`
+	PhaseChangedAt    time.Time              `json:"phaseChangedAt"`
+	LastInstructionAt *time.Time             `json:"lastInstructionAt,omitempty"`
+}
+
+// Page sizes of the items query
+const (
+	DefaultItemsPageSize = 100
+	MaxItemsPageSize     = 1000
+)
+
+var invalidPageTokenError = errors.New("invalid page token")
+
+// ItemsQueryRequest is the argument of the items query. Empty filters match all items.
+type ItemsQueryRequest struct {
+	PageSize  int      `json:"pageSize,omitempty"`  // DefaultItemsPageSize if not positive, at most MaxItemsPageSize
+	PageToken string   `json:"pageToken,omitempty"` // NextPageToken of the previous page, empty for the first page
+	Statuses  []string `json:"statuses,omitempty"`  // item statuses, matched case-insensitively
+	Types     []string `json:"types,omitempty"`     // item type names, see ItemTypes
+	IDPrefix  string   `json:"idPrefix,omitempty"`
+}
+
+// ItemsQueryResponse is a page of the items matching an ItemsQueryRequest. Registered items come first, ordered by
+// registration time, followed by the queued items in queue order. Pages are not a consistent snapshot: items that
+// change between two queries may be skipped or returned twice.
+type ItemsQueryResponse struct {
+	Items         []OrchestratedItem `json:"items,omitempty"`
+	QueuedItems   []QueuedItem       `json:"queuedItems,omitempty"`
+	NextPageToken string             `json:"nextPageToken,omitempty"` // empty on the last page
+}
+
+// SummaryResponse represents the response for the summary query, which returns counts instead of items.
+type SummaryResponse struct {
+	TotalItems        int                `json:"totalItems"` // registered and deregistered items, like QueryResponse.TotalItems
+	RegisteredItems   int                `json:"registeredItems"`
+	InProgressItems   int                `json:"inProgressItems"`
+	DeregisteredItems int                `json:"deregisteredItems"`
+	QueuedItems       int                `json:"queuedItems"`
+	Statuses          map[string]int     `json:"statuses"` // items per item status, including queued items
+	Types             map[string]int     `json:"types"`    // items per item type, including queued items
+	SignalsHandled    int                `json:"signalsHandled"`
+	Config            OrchestratorConfig `json:"config"`
+	PrunedItems       []PrunedItem       `json:"prunedItems,omitempty"` // pruned items whose claim check blobs are not deleted yet
+}
+
+// ItemLookupResponse represents the response for the item lookup query. Both items are nil if the ID is unknown.
+type ItemLookupResponse struct {
+	Item       *OrchestratedItem `json:"item,omitempty"`
+	QueuedItem *QueuedItem       `json:"queuedItem,omitempty"`
+}
+
+// itemsPageToken is the position of the last item of a page, encoded as the NextPageToken.
+type itemsPageToken struct {
+	Queued       bool      `json:"queued,omitempty"`
+	RegisteredAt time.Time `json:"registeredAt"`
+	ID           string    `json:"id"`
+}
+
+func (t itemsPageToken) encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeItemsPageToken(token string) (*itemsPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalidPageTokenError
+	}
+	var t itemsPageToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, invalidPageTokenError
+	}
+	return &t, nil
+}
+
+func (r ItemsQueryRequest) matches(id string, workflowName string, payload interface{}) bool {
+	if !strings.HasPrefix(id, r.IDPrefix) {
+		return false
+	}
+	if len(r.Statuses) > 0 && !ContainsFold(r.Statuses, PayloadStatus(payload)) {
+		return false
+	}
+	if len(r.Types) > 0 && !ContainsFold(r.Types, ItemTypeName(workflowName)) {
+		return false
+	}
+	return true
+}
+
+// QueryItems returns the page of items and queued items selected by request.
+func QueryItems(items map[string]OrchestratedItem, queued []QueuedItem, request ItemsQueryRequest) (ItemsQueryResponse, error) {
+	var after *itemsPageToken
+	if request.PageToken != "" {
+		var err error
+		if after, err = decodeItemsPageToken(request.PageToken); err != nil {
+			return ItemsQueryResponse{}, err
+		}
+	}
+	pageSize := request.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultItemsPageSize
+	}
+	pageSize = min(pageSize, MaxItemsPageSize)
+
+	var resp ItemsQueryResponse
+	full := func() bool { return len(resp.Items)+len(resp.QueuedItems) == pageSize }
+
+	if after == nil || !after.Queued {
+		registered := make([]OrchestratedItem, 0, len(items))
+		for _, item := range items {
+			if request.matches(item.ID, item.WorkflowName, item.Payload) {
+				registered = append(registered, item)
+			}
+		}
+		sort.Slice(registered, func(i, j int) bool {
+			return registered[j].registeredAfter(registered[i].RegisteredAt, registered[i].ID)
+		})
+		start := 0
+		if after != nil {
+			start = sort.Search(len(registered), func(i int) bool { return registered[i].registeredAfter(after.RegisteredAt, after.ID) })
+		}
+		for _, item := range registered[start:] {
+			if full() {
+				resp.NextPageToken = resp.lastItemToken()
+				return resp, nil
+			}
+			resp.Items = append(resp.Items, item)
+		}
+	}
+
+	// Queued items only leave the queue at the front, so if the last item of the previous page was launched
+	// in the meantime, the page continues with the first queued item.
+	start := 0
+	if after != nil && after.Queued {
+		for i, item := range queued {
+			if item.ID == after.ID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	for _, item := range queued[start:] {
+		if !request.matches(item.ID, item.WorkflowName, item.Payload) {
+			continue
+		}
+		if full() {
+			resp.NextPageToken = resp.lastItemToken()
+			return resp, nil
+		}
+		resp.QueuedItems = append(resp.QueuedItems, item)
+	}
+	return resp, nil
+}
+
+// lastItemToken returns the page token pointing after the last item of a non-empty page.
+func (r ItemsQueryResponse) lastItemToken() string {
+	if n := len(r.QueuedItems); n > 0 {
+		return itemsPageToken{Queued: true, ID: r.QueuedItems[n-1].ID}.encode()
+	}
+	last := r.Items[len(r.Items)-1]
+	return itemsPageToken{RegisteredAt: last.RegisteredAt, ID: last.ID}.encode()
+}
+
+// registeredAfter reports whether the item comes after the given position in registration order, ties are
+// ordered by ID.
+func (item OrchestratedItem) registeredAfter(registeredAt time.Time, id string) bool {
+	if !item.RegisteredAt.Equal(registeredAt) {
+		return item.RegisteredAt.After(registeredAt)
+	}
+	return item.ID > id
+}
+
+// Summarize counts the items and queued items. SignalsHandled, Config and PrunedItems are left to the caller.
+func Summarize(items map[string]OrchestratedItem, queued []QueuedItem) SummaryResponse {
+	summary := SummaryResponse{
+		TotalItems:  len(items),
+		QueuedItems: len(queued),
+		Statuses:    make(map[string]int),
+		Types:       make(map[string]int),
+	}
+	for _, item := range items {
+		switch {
+		case item.Deregistered:
+			summary.DeregisteredItems++
+		case item.InProgress:
+			summary.InProgressItems++
+			summary.RegisteredItems++
+		default:
+			summary.RegisteredItems++
+		}
+		summary.Statuses[PayloadStatus(item.Payload)]++
+		summary.Types[ItemTypeName(item.WorkflowName)]++
+	}
+	for _, item := range queued {
+		summary.Statuses[PayloadStatus(item.Payload)]++
+		summary.Types[ItemTypeName(item.WorkflowName)]++
+	}
+	return summary
+}
+
+// LookupItem finds the item or queued item with the given ID.
+func LookupItem(items map[string]OrchestratedItem, queued []QueuedItem, id string) ItemLookupResponse {
+	if item, exists := items[id]; exists {
+		return ItemLookupResponse{Item: &item}
+	}
+	for _, item := range queued {
+		if item.ID == id {
+			return ItemLookupResponse{QueuedItem: &item}
+		}
+	}
+	return ItemLookupResponse{}
+}
+
+// ItemTypeName returns the name of the item type of a workflow, or the workflow name if the type is unknown.
+func ItemTypeName(workflowName string) string {
+	if itemType, known := ItemTypes.LookupWorkflow(workflowName); known {
+		return itemType.Name
+	}
+	return workflowName
+}
+
+// ContainsFold reports whether values contains s, ignoring case. Item types and statuses are filtered with it.
+func ContainsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
 }
@@ -2,47 +2,164 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"flag"
+	"fmt"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/logging"
 	"my-samples-go/temporal/orchestrator"
-
-	"go.temporal.io/sdk/client"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 )
 
 func main() {
-	c, err := client.Dial(client.Options{
-		HostPort: "passthrough:///localhost:7233",
-	})
+	itemWorkflowID := flag.String("item", "", "describe a single item workflow by its workflow ID instead of listing the orchestrator state")
+	summary := flag.Bool("summary", false, "only show the item counts of the orchestrator instead of listing its items")
+	watch := flag.Bool("watch", false, "continuously re-query the orchestrator and show its state as a live view, until interrupted")
+	interval := flag.Duration("interval", 2*time.Second, "refresh interval of -watch")
+	noColor := flag.Bool("no-color", os.Getenv("NO_COLOR") != "", "do not highlight changes in -watch mode")
+	output := flag.String("output", orchestratorclient.FormatTable, "output format: "+strings.Join(orchestratorclient.Formats, ", "))
+	var filter orchestratorclient.ItemFilter
+	filter.RegisterFlags(flag.CommandLine)
+	cfg := config.New(orchestrator.TaskQueueName)
+	// Only the query result goes to stdout, so it can be piped into other tools.
+	cfg.LogLevel = "warn"
+	cfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	if err := cfg.Load(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	cfg.SetDefaultLogger()
+
+	if err := orchestratorclient.ValidateFormat(*output); err != nil {
+		logging.Fatal("Invalid output format", "error", err)
+	}
+	if err := filter.Validate(); err != nil {
+		logging.Fatal("Invalid filter", "error", err)
+	}
+
+	shutdownTracing, err := cfg.StartTracing(context.Background(), "orchestrator-query")
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	c, err := cfg.Dial()
 	if err != nil {
-		log.Fatalln("Unable to create Temporal client", err)
+		logging.Fatal("Unable to create Temporal client", "error", err)
 	}
 	defer c.Close()
 
-	workflowID := orchestrator.OrchestratorWorkflowID
 	ctx := context.Background()
+	oc := orchestratorclient.New(c, cfg.TaskQueue)
+
+	if *watch {
+		if *itemWorkflowID != "" || *interval <= 0 {
+			logging.Fatal("-watch requires a positive -interval and cannot be combined with -item")
+		}
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		watcher := &orchestratorclient.Watcher{
+			Client:   oc,
+			Filter:   filter,
+			Interval: *interval,
+			Out:      os.Stdout,
+			Color:    !*noColor,
+		}
+		if err := watcher.Run(ctx); err != nil {
+			logging.Fatal("Watch failed", "error", err)
+		}
+		return
+	}
 
-	// Query the workflow for registered items
-	resp, err := c.QueryWorkflow(ctx, workflowID, "", orchestrator.QueryName)
+	if *itemWorkflowID != "" {
+		itemState, err := oc.DescribeItem(ctx, *itemWorkflowID)
+		if err != nil {
+			logging.Fatal("Query failed", "error", err)
+		}
+		if *output == orchestratorclient.FormatTable {
+			printItemState(itemState)
+			return
+		}
+		if err := orchestratorclient.WriteValue(os.Stdout, *output, itemState); err != nil {
+			logging.Fatal("Unable to write output", "error", err)
+		}
+		return
+	}
+
+	if *summary {
+		summaryResult, err := oc.QuerySummary(ctx)
+		if err != nil {
+			logging.Fatal("Query failed", "error", err)
+		}
+		if *output == orchestratorclient.FormatTable {
+			printSummary(summaryResult)
+			return
+		}
+		if err := orchestratorclient.WriteValue(os.Stdout, *output, summaryResult); err != nil {
+			logging.Fatal("Unable to write output", "error", err)
+		}
+		return
+	}
+
+	queryResult, err := oc.QueryState(ctx, filter.QueryRequest())
 	if err != nil {
-		log.Fatalln("Unable to query workflow", err)
+		logging.Fatal("Query failed", "error", err)
 	}
 
-	var queryResult orchestrator.QueryResponse
-	if err := resp.Get(&queryResult); err != nil {
-		log.Fatalln("Unable to decode query result", err)
+	rows := orchestratorclient.ItemRows(queryResult, filter)
+	if *output == orchestratorclient.FormatTable {
+		fmt.Printf("Total Items: %d, Queued Items: %d, Signals Handled: %d, Paused: %t, Draining: %t\n\n",
+			queryResult.TotalItems, len(queryResult.QueuedItems), queryResult.SignalsHandled, queryResult.Config.Paused, queryResult.Config.Draining)
 	}
+	if err := orchestratorclient.WriteItems(os.Stdout, *output, rows); err != nil {
+		logging.Fatal("Unable to write output", "error", err)
+	}
+}
 
-	log.Printf("Workflow State:\n")
-	log.Printf("  Total Items: %d\n", queryResult.TotalItems)
-	log.Printf("  Signals Handled: %d\n", queryResult.SignalsHandled)
+func printSummary(summary *orchestrator.SummaryResponse) {
+	fmt.Printf("Total Items: %d (registered %d, in progress %d, deregistered %d), Queued Items: %d\n",
+		summary.TotalItems, summary.RegisteredItems, summary.InProgressItems, summary.DeregisteredItems, summary.QueuedItems)
+	fmt.Printf("Signals Handled: %d, Paused: %t, Draining: %t, Pruned Items With Blobs: %d\n",
+		summary.SignalsHandled, summary.Config.Paused, summary.Config.Draining, len(summary.PrunedItems))
+	printCounts("STATUS", summary.Statuses)
+	printCounts("TYPE", summary.Types)
+}
 
-	if queryResult.TotalItems > 0 {
-		log.Printf("  Orchestrated Items:\n")
-		for id, item := range queryResult.OrchestratedItems {
-			itemJSON, _ := json.MarshalIndent(item, "    ", "  ")
-			log.Printf("    %s: %s\n", id, string(itemJSON))
+func printCounts(heading string, counts map[string]int) {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "\n%s\tITEMS\n", heading)
+	for _, key := range keys {
+		name := key
+		if name == "" {
+			name = "-"
 		}
+		fmt.Fprintf(tw, "%s\t%d\n", name, counts[key])
+	}
+	tw.Flush()
+}
+
+func printItemState(itemState *orchestrator.ItemQueryResponse) {
+	fmt.Printf("Item ID:     %s\n", itemState.ID)
+	fmt.Printf("Workflow ID: %s\n", itemState.ItemWorkflowID)
+	fmt.Printf("Run ID:      %s\n", itemState.ItemWorkflowRunID)
+	fmt.Printf("Phase:       %s (since %s)\n", itemState.Phase, itemState.PhaseChangedAt)
+	fmt.Printf("Status:      %s\n", itemState.Status)
+	fmt.Printf("Retry Count: %d\n", itemState.RetryCount)
+	fmt.Printf("Started At:  %s\n", itemState.StartedAt)
+	if itemState.LastInstruction != nil {
+		fmt.Printf("Last Instruction: proceed=%t reason=%q (at %s)\n", itemState.LastInstruction.Proceed, itemState.LastInstruction.Reason, itemState.LastInstructionAt)
 	} else {
-		log.Printf("  No items currently registered\n")
+		fmt.Printf("No instruction received yet\n")
 	}
 }
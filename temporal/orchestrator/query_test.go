@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testItems() (map[string]OrchestratedItem, []QueuedItem) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	items := make(map[string]OrchestratedItem)
+	for i := 1; i <= 5; i++ {
+		id := fmt.Sprintf("item-%d", i)
+		items[id] = OrchestratedItem{
+			ID:           id,
+			WorkflowName: ItemWorkflowAName,
+			RegisteredAt: start.Add(time.Duration(i/2) * time.Minute), // item-2 and item-3 were registered at the same time
+			Deregistered: i < 4,
+			InProgress:   i == 4,
+			Payload:      map[string]interface{}{"id": id, "status": "Finished"},
+		}
+	}
+	queued := []QueuedItem{
+		{ID: "queued-1", WorkflowName: ItemWorkflowBName, Payload: map[string]interface{}{"id": "queued-1", "status": "New"}},
+		{ID: "queued-2", WorkflowName: ItemWorkflowAName, Payload: map[string]interface{}{"id": "queued-2", "status": "New"}},
+	}
+	return items, queued
+}
+
+func queryAllItems(t *testing.T, items map[string]OrchestratedItem, queued []QueuedItem, request ItemsQueryRequest) ([]string, int) {
+	var ids []string
+	pages := 0
+	for {
+		resp, err := QueryItems(items, queued, request)
+		require.NoError(t, err)
+		pages++
+		for _, item := range resp.Items {
+			ids = append(ids, item.ID)
+		}
+		for _, item := range resp.QueuedItems {
+			ids = append(ids, item.ID)
+		}
+		if resp.NextPageToken == "" {
+			return ids, pages
+		}
+		request.PageToken = resp.NextPageToken
+	}
+}
+
+func Test_QueryItems_Pagination(t *testing.T) {
+	items, queued := testItems()
+	all := []string{"item-1", "item-2", "item-3", "item-4", "item-5", "queued-1", "queued-2"}
+
+	ids, pages := queryAllItems(t, items, queued, ItemsQueryRequest{})
+	require.Equal(t, all, ids)
+	require.Equal(t, 1, pages)
+
+	for pageSize := 1; pageSize <= len(all); pageSize++ {
+		ids, pages = queryAllItems(t, items, queued, ItemsQueryRequest{PageSize: pageSize})
+		require.Equal(t, all, ids, "page size %d", pageSize)
+		require.Equal(t, (len(all)+pageSize-1)/pageSize, pages, "page size %d", pageSize)
+	}
+
+	// The page continues with the first queued item once the last queued item of the previous page was launched.
+	resp, err := QueryItems(items, queued, ItemsQueryRequest{PageSize: 6})
+	require.NoError(t, err)
+	require.Equal(t, "queued-1", resp.QueuedItems[0].ID)
+	resp, err = QueryItems(items, queued[1:], ItemsQueryRequest{PageSize: 6, PageToken: resp.NextPageToken})
+	require.NoError(t, err)
+	require.Empty(t, resp.Items)
+	require.Equal(t, "queued-2", resp.QueuedItems[0].ID)
+
+	_, err = QueryItems(items, queued, ItemsQueryRequest{PageToken: "not a token"})
+	require.ErrorIs(t, err, invalidPageTokenError)
+}
+
+func Test_QueryItems_Filters(t *testing.T) {
+	items, queued := testItems()
+
+	ids, _ := queryAllItems(t, items, queued, ItemsQueryRequest{Statuses: []string{"new"}})
+	require.Equal(t, []string{"queued-1", "queued-2"}, ids)
+
+	ids, _ = queryAllItems(t, items, queued, ItemsQueryRequest{Types: []string{"B"}})
+	require.Equal(t, []string{"queued-1"}, ids)
+
+	ids, _ = queryAllItems(t, items, queued, ItemsQueryRequest{IDPrefix: "item-", Statuses: []string{"Finished"}, PageSize: 2})
+	require.Equal(t, []string{"item-1", "item-2", "item-3", "item-4", "item-5"}, ids)
+}
+
+func Test_Summarize(t *testing.T) {
+	items, queued := testItems()
+	summary := Summarize(items, queued)
+	require.Equal(t, 5, summary.TotalItems)
+	require.Equal(t, 2, summary.RegisteredItems)
+	require.Equal(t, 1, summary.InProgressItems)
+	require.Equal(t, 3, summary.DeregisteredItems)
+	require.Equal(t, 2, summary.QueuedItems)
+	require.Equal(t, map[string]int{"Finished": 5, "New": 2}, summary.Statuses)
+	require.Equal(t, map[string]int{"a": 6, "b": 1}, summary.Types)
+}
+
+func Test_LookupItem(t *testing.T) {
+	items, queued := testItems()
+	require.Equal(t, "item-4", LookupItem(items, queued, "item-4").Item.ID)
+	require.Equal(t, "queued-2", LookupItem(items, queued, "queued-2").QueuedItem.ID)
+	require.Equal(t, ItemLookupResponse{}, LookupItem(items, queued, "item-9"))
+}
@@ -0,0 +1,182 @@
+package orchestrator
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ItemField is a type specific item attribute, exposed as a CLI flag by the clients.
+type ItemField struct {
+	Name    string // field name, also used as the CLI flag name
+	Usage   string
+	Default string
+}
+
+// ItemType describes a kind of item the orchestrator can process.
+// Adding a new item type only requires declaring it and adding it to ItemTypes.
+type ItemType struct {
+	Name         string      // type name used by clients, e.g. "a"
+	WorkflowName string      // name the item workflow is registered under
+	Workflow     interface{} // item workflow function
+	Fields       []ItemField
+	// NewItem builds the item payload. fields holds the values of Fields by name,
+	// an empty name is replaced with a generated one.
+	NewItem func(id string, name string, fields map[string]string) Item
+}
+
+// RegisterFlags registers the type's fields on fs, and returns a function
+// that reads the field values once fs has been parsed.
+func (t ItemType) RegisterFlags(fs *flag.FlagSet) func() map[string]string {
+	values := make(map[string]*string, len(t.Fields))
+	for _, field := range t.Fields {
+		values[field.Name] = fs.String(field.Name, field.Default, field.Usage+" (item type "+t.Name+")")
+	}
+	return func() map[string]string {
+		fields := make(map[string]string, len(values))
+		for name, value := range values {
+			fields[name] = *value
+		}
+		return fields
+	}
+}
+
+// FieldValues returns the values of the type's fields, with the defaults for the fields missing from values.
+// It fails if values contains a field the type does not have.
+func (t ItemType) FieldValues(values map[string]string) (map[string]string, error) {
+	fields := make(map[string]string, len(t.Fields))
+	for _, field := range t.Fields {
+		fields[field.Name] = field.Default
+	}
+	for name, value := range values {
+		if _, known := fields[name]; !known {
+			return nil, fmt.Errorf("%w: %q for item type %s", unknownItemFieldError, name, t.Name)
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
+
+// ItemTypeRegistry holds the item types known to the starter and the worker.
+type ItemTypeRegistry struct {
+	types map[string]ItemType
+}
+
+var (
+	unknownItemTypeError    = errors.New("unknown item type")
+	duplicateItemTypeError  = errors.New("item type already registered")
+	incompleteItemTypeError = errors.New("item type must have a name, workflow name, workflow and item factory")
+	unknownItemFieldError   = errors.New("unknown item field")
+)
+
+// ItemTypes is the registry of all item types supported by this sample.
+var ItemTypes = MustNewItemTypeRegistry(ItemTypeA, ItemTypeB)
+
+func NewItemTypeRegistry(types ...ItemType) (*ItemTypeRegistry, error) {
+	r := &ItemTypeRegistry{types: make(map[string]ItemType)}
+	for _, t := range types {
+		if err := r.Register(t); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func MustNewItemTypeRegistry(types ...ItemType) *ItemTypeRegistry {
+	r, err := NewItemTypeRegistry(types...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (r *ItemTypeRegistry) Register(t ItemType) error {
+	if t.Name == "" || t.WorkflowName == "" || t.Workflow == nil || t.NewItem == nil {
+		return incompleteItemTypeError
+	}
+	key := strings.ToLower(t.Name)
+	if _, exists := r.types[key]; exists {
+		return fmt.Errorf("%w: %s", duplicateItemTypeError, t.Name)
+	}
+	if _, exists := r.LookupWorkflow(t.WorkflowName); exists {
+		return fmt.Errorf("%w: workflow %s", duplicateItemTypeError, t.WorkflowName)
+	}
+	r.types[key] = t
+	return nil
+}
+
+// Lookup returns the item type with the given name (case-insensitive).
+// The error for an unknown type lists the valid ones.
+func (r *ItemTypeRegistry) Lookup(name string) (ItemType, error) {
+	if t, exists := r.types[strings.ToLower(name)]; exists {
+		return t, nil
+	}
+	return ItemType{}, fmt.Errorf("%w %q, valid types are: %s", unknownItemTypeError, name, strings.Join(r.Names(), ", "))
+}
+
+// LookupWorkflow returns the item type whose workflow is registered under workflowName.
+func (r *ItemTypeRegistry) LookupWorkflow(workflowName string) (ItemType, bool) {
+	for _, t := range r.types {
+		if t.WorkflowName == workflowName {
+			return t, true
+		}
+	}
+	return ItemType{}, false
+}
+
+// Types returns all registered item types, sorted by name.
+func (r *ItemTypeRegistry) Types() []ItemType {
+	types := make([]ItemType, 0, len(r.types))
+	for _, name := range r.Names() {
+		types = append(types, r.types[name])
+	}
+	return types
+}
+
+// Names returns the names of all registered item types, sorted.
+func (r *ItemTypeRegistry) Names() []string {
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var ItemTypeA = ItemType{
+	Name:         "a",
+	WorkflowName: ItemWorkflowAName,
+	Workflow:     ItemWorkflowA,
+	Fields: []ItemField{
+		{Name: "extra-a", Usage: "value of ExtraFieldA", Default: "Extra data for Item A"},
+	},
+	NewItem: func(id string, name string, fields map[string]string) Item {
+		if name == "" {
+			name = fmt.Sprintf("Item-A-%s", id)
+		}
+		return &ItemA{
+			BasicItem:   BasicItem{Id: id, Name: name},
+			ExtraFieldA: fields["extra-a"],
+		}
+	},
+}
+
+var ItemTypeB = ItemType{
+	Name:         "b",
+	WorkflowName: ItemWorkflowBName,
+	Workflow:     ItemWorkflowB,
+	Fields: []ItemField{
+		{Name: "extra-b", Usage: "value of ExtraFieldB", Default: "Extra data for Item B"},
+	},
+	NewItem: func(id string, name string, fields map[string]string) Item {
+		if name == "" {
+			name = fmt.Sprintf("Item-B-%s", id)
+		}
+		return &ItemB{
+			BasicItem:   BasicItem{Id: id, Name: name},
+			ExtraFieldB: fields["extra-b"],
+		}
+	},
+}
@@ -0,0 +1,56 @@
+package orchestrator
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ItemTypeRegistry_Lookup(t *testing.T) {
+	itemType, err := ItemTypes.Lookup("B")
+	require.NoError(t, err)
+	require.Equal(t, ItemWorkflowBName, itemType.WorkflowName)
+
+	_, err = ItemTypes.Lookup("c")
+	require.ErrorIs(t, err, unknownItemTypeError)
+	require.ErrorContains(t, err, "valid types are: a, b")
+
+	itemType, known := ItemTypes.LookupWorkflow(ItemWorkflowAName)
+	require.True(t, known)
+	require.Equal(t, "a", itemType.Name)
+}
+
+func Test_ItemTypeRegistry_Register(t *testing.T) {
+	_, err := NewItemTypeRegistry(ItemTypeA, ItemTypeA)
+	require.ErrorIs(t, err, duplicateItemTypeError)
+
+	_, err = NewItemTypeRegistry(ItemType{Name: "c"})
+	require.ErrorIs(t, err, incompleteItemTypeError)
+}
+
+func Test_ItemType_RegisterFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fieldsA := ItemTypeA.RegisterFlags(fs)
+	fieldsB := ItemTypeB.RegisterFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-extra-a", "custom"}))
+
+	item := ItemTypeA.NewItem("item-1", "", fieldsA())
+	require.Equal(t, &ItemA{BasicItem: BasicItem{Id: "item-1", Name: "Item-A-item-1"}, ExtraFieldA: "custom"}, item)
+
+	item = ItemTypeB.NewItem("item-2", "named", fieldsB())
+	require.Equal(t, &ItemB{BasicItem: BasicItem{Id: "item-2", Name: "named"}, ExtraFieldB: "Extra data for Item B"}, item)
+}
+
+func Test_ItemType_FieldValues(t *testing.T) {
+	fields, err := ItemTypeA.FieldValues(nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"extra-a": "Extra data for Item A"}, fields)
+
+	fields, err = ItemTypeA.FieldValues(map[string]string{"extra-a": "custom"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"extra-a": "custom"}, fields)
+
+	_, err = ItemTypeA.FieldValues(map[string]string{"extra-b": "custom"})
+	require.ErrorIs(t, err, unknownItemFieldError)
+}
@@ -15,11 +15,21 @@ echo "Run this command: go run orchestrator/worker/*.go"
 echo ""
 read -p "Press [Enter] to start the demo..."
 
+# start_item starts an item workflow without waiting for it and prints its workflow ID.
+start_item() {
+  go run ./orchestrator/starter -detach "$1" "$2" | sed -n 's/.*WorkflowID: \([^,]*\),.*/\1/p'
+}
+
+# wait_item waits for a started item workflow and prints its outcome.
+wait_item() {
+  go run ./orchestrator/orchestratorctl result -timeout 5m "$2" && echo "✅ $1 finished."
+}
+
 echo ""
 echo "Step 1: Starting Item A ('item-a-1')."
 echo "This workflow should register successfully and, after a 30s delay, start processing."
-(go run orchestrator/starter/main.go a item-a-1 && echo "✅ item-a-1 finished.") &
-echo "-> Started item-a-1 in the background."
+ITEM_A1=$(start_item a item-a-1)
+echo "-> Started item-a-1, workflow ID $ITEM_A1."
 echo ""
 
 echo "Step 2: Waiting 10 seconds..."
@@ -29,8 +39,8 @@ echo ""
 echo "Step 3: Starting Item B ('item-b-1')."
 echo "At this point, item-a-1 is waiting but not yet processing, so item-b-1 should also register successfully."
 echo "It will be queued to start processing later if Orchestrator permits."
-(go run orchestrator/starter/main.go b item-b-1 && echo "✅ item-b-1 finished.") &
-echo "-> Started item-b-1 in the background."
+ITEM_B1=$(start_item b item-b-1)
+echo "-> Started item-b-1, workflow ID $ITEM_B1."
 echo ""
 
 echo "Step 4: Waiting 30 seconds..."
@@ -41,17 +51,19 @@ echo ""
 
 echo "Step 5: Starting Item A ('item-a-2') while item-a-1 is processing."
 echo "Because item-a-1 is now 'in-progress', the orchestrator's rules should REJECT the registration of this new workflow."
-echo "You should see a 'Workflow failed: Halted by orchestrator' message for item-a-2 shortly."
-(go run orchestrator/starter/main.go a item-a-2 && echo "✅ item-a-2 finished.") &
-echo "-> Started item-a-2 in the background."
+echo "Its result in step 6 should be a 'workflow failed: Halted by orchestrator' message."
+ITEM_A2=$(start_item a item-a-2)
+echo "-> Started item-a-2, workflow ID $ITEM_A2."
 echo ""
 
-echo "Step 6: Demo in progress. Monitoring background jobs..."
+echo "Step 6: Demo in progress. Waiting for the item workflows to finish..."
 echo "You can also query the orchestrator state in another terminal:"
-echo "go run orchestrator/query/main.go"
+echo "go run orchestrator/query/main.go -watch"
 echo ""
 
-wait
+wait_item item-a-1 "$ITEM_A1"
+wait_item item-b-1 "$ITEM_B1"
+wait_item item-a-2 "$ITEM_A2"
 
 echo ""
 echo "Step 7: All initial workflows have completed. Now demonstrating successful registration after completion..."
@@ -61,8 +73,8 @@ sleep 5
 
 echo ""
 echo "Starting item-b-2..."
-(go run orchestrator/starter/main.go b item-b-2 && echo "✅ item-b-2 finished successfully.") &
-echo "-> Started item-b-2 in the background."
+ITEM_B2=$(start_item b item-b-2)
+echo "-> Started item-b-2, workflow ID $ITEM_B2."
 echo ""
 
 echo "Step 8: Waiting for item-b-2 to complete..."
@@ -70,10 +82,10 @@ echo "This demonstrates that the orchestrator correctly allows new workflows to
 echo "and process when the system is no longer busy."
 echo ""
 
-wait
+wait_item item-b-2 "$ITEM_B2"
 
 echo "--- Demo Complete ---"
-echo "All background workflow starters have finished."
+echo "All item workflows have finished."
 echo ""
 echo "Summary of what was demonstrated:"
 echo "1. ✅ Concurrent registration of workflows (item-a-1 and item-b-1)"
@@ -0,0 +1,112 @@
+package orchestrator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Search attributes Temporal sets on the workflows started by a schedule.
+var (
+	scheduledByIDSearchAttribute      = temporal.NewSearchAttributeKeyKeyword("TemporalScheduledById")
+	scheduledStartTimeSearchAttribute = temporal.NewSearchAttributeKeyTime("TemporalScheduledStartTime")
+)
+
+var invalidItemIDTemplateError = errors.New("invalid item ID template")
+
+// ItemIDTemplateData is the data an item ID template is executed with, e.g. "report-{{.Date}}".
+type ItemIDTemplateData struct {
+	ScheduleID    string    // ID of the schedule that started the item workflow, empty if it was not scheduled
+	ScheduledTime time.Time // time the item workflow was scheduled for in UTC, its start time if it was not scheduled
+	Date          string    // ScheduledTime as 2006-01-02
+	Timestamp     string    // ScheduledTime as 20060102T150405Z
+}
+
+// NewItemIDTemplateData returns the template data of an item workflow scheduled at scheduledTime.
+func NewItemIDTemplateData(scheduleID string, scheduledTime time.Time) ItemIDTemplateData {
+	scheduledTime = scheduledTime.UTC()
+	return ItemIDTemplateData{
+		ScheduleID:    scheduleID,
+		ScheduledTime: scheduledTime,
+		Date:          scheduledTime.Format(time.DateOnly),
+		Timestamp:     scheduledTime.Format("20060102T150405Z"),
+	}
+}
+
+// IsItemIDTemplate reports whether the item ID is a template to be expanded by the item workflow.
+func IsItemIDTemplate(id string) bool {
+	return strings.Contains(id, "{{")
+}
+
+// ExpandItemID executes the item ID template with data.
+func ExpandItemID(id string, data ItemIDTemplateData) (string, error) {
+	tmpl, err := template.New("item-id").Option("missingkey=error").Parse(id)
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", invalidItemIDTemplateError, id, err)
+	}
+	var expanded strings.Builder
+	if err := tmpl.Execute(&expanded, data); err != nil {
+		return "", fmt.Errorf("%w %q: %w", invalidItemIDTemplateError, id, err)
+	}
+	if expanded.Len() == 0 {
+		return "", fmt.Errorf("%w %q: expands to an empty ID", invalidItemIDTemplateError, id)
+	}
+	return expanded.String(), nil
+}
+
+// expandItemIDTemplate replaces a templated item ID, as set by schedules, with its expansion for this run.
+// Plain IDs are left alone, so the item workflows of unscheduled items issue the same commands as before.
+func expandItemIDTemplate[T Item](ctx workflow.Context, item *T) {
+	id := (*item).ID()
+	if !IsItemIDTemplate(id) {
+		return
+	}
+	expanded, err := expandItemID(ctx, id)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("Keeping the item ID as is", "error", err)
+		return
+	}
+	if setter, ok := any(item).(interface{ setID(id string) }); ok {
+		setter.setID(expanded)
+	}
+}
+
+// ScheduledItemWorkflow is the action of the item schedules. Rather than starting the item workflow itself, which
+// the orchestrator would halt while another item holds the processing slot, and which could not register once the
+// orchestrator exited after its idle timeout, it expands the item ID template and submits the item with the
+// SubmitItemActivityName activity, which starts the orchestrator if needed (pull model). It returns the item ID.
+func ScheduledItemWorkflow(ctx workflow.Context, submit SubmitPayload) (string, error) {
+	if IsItemIDTemplate(submit.ID) {
+		id, err := expandItemID(ctx, submit.ID)
+		if err != nil {
+			return "", err
+		}
+		submit.ID = id
+		// The item workflow is launched by the orchestrator, without the schedule's search attributes.
+		if item, ok := submit.Item.(map[string]interface{}); ok {
+			item["id"] = id
+		}
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	if err := workflow.ExecuteActivity(ctx, SubmitItemActivityName, submit).Get(ctx, nil); err != nil {
+		return "", fmt.Errorf("unable to submit item %s: %w", submit.ID, err)
+	}
+	return submit.ID, nil
+}
+
+// expandItemID executes the item ID template with the schedule and time the workflow was started by.
+func expandItemID(ctx workflow.Context, id string) (string, error) {
+	attributes := workflow.GetTypedSearchAttributes(ctx)
+	scheduleID, _ := attributes.GetKeyword(scheduledByIDSearchAttribute)
+	scheduledTime, scheduled := attributes.GetTime(scheduledStartTimeSearchAttribute)
+	if !scheduled {
+		scheduledTime = workflow.GetInfo(ctx).WorkflowStartTime
+	}
+	return ExpandItemID(id, NewItemIDTemplateData(scheduleID, scheduledTime))
+}
@@ -0,0 +1,110 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func Test_ExpandItemID(t *testing.T) {
+	data := NewItemIDTemplateData("daily", time.Date(2026, 10, 18, 6, 30, 0, 0, time.FixedZone("CEST", 2*60*60)))
+
+	for template, expected := range map[string]string{
+		"report-{{.Date}}":                         "report-2026-10-18",
+		"{{.ScheduleID}}-{{.Timestamp}}":           "daily-20261018T043000Z",
+		`week-{{.ScheduledTime.Format "2006-01"}}`: "week-2026-10",
+		"plain": "plain",
+	} {
+		expanded, err := ExpandItemID(template, data)
+		require.NoError(t, err, template)
+		require.Equal(t, expected, expanded, template)
+	}
+
+	for _, template := range []string{"report-{{.Day}}", "report-{{.Date", "{{if false}}x{{end}}"} {
+		_, err := ExpandItemID(template, data)
+		require.ErrorIs(t, err, invalidItemIDTemplateError, template)
+	}
+}
+
+func Test_ItemWorkflowA_ScheduledItemID(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(ItemWorkflowA)
+
+	// The test environment only sets the search attributes of child workflows, so a parent stands in for the schedule.
+	scheduledTime := time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)
+	schedule := func(ctx workflow.Context, item ItemA) (string, error) {
+		ctx = workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: "item_daily",
+			TypedSearchAttributes: temporal.NewSearchAttributes(
+				scheduledByIDSearchAttribute.ValueSet("daily"),
+				scheduledStartTimeSearchAttribute.ValueSet(scheduledTime),
+			),
+		})
+		var result string
+		err := workflow.ExecuteChildWorkflow(ctx, ItemWorkflowA, item).Get(ctx, &result)
+		return result, err
+	}
+
+	var registered RegisterPayload
+	env.OnSignalExternalWorkflow(mock.Anything, OrchestratorWorkflowID, "", SignalChannelName, mock.Anything).Run(func(args mock.Arguments) {
+		sig := args.Get(4).(Signal)
+		if sig.Type == RegisterSignal {
+			registered = sig.Payload.(RegisterPayload)
+		}
+	}).Return(nil)
+	env.RegisterDelayedCallback(func() {
+		require.Equal(t, "daily-report-2026-10-18", registered.ID)
+		require.NoError(t, env.SignalWorkflowByID("item_daily", ItemSignalChannelName, ItemInstructionSignal{ID: registered.ID, Proceed: false, Reason: "Halted."}))
+	}, time.Second)
+
+	env.ExecuteWorkflow(schedule, ItemA{BasicItem: BasicItem{Id: "{{.ScheduleID}}-report-{{.Date}}"}})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Equal(t, "daily-report-2026-10-18", registered.Item.(ItemA).ID())
+}
+
+func Test_ScheduledItemWorkflow(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflowWithOptions(ScheduledItemWorkflow, workflow.RegisterOptions{Name: ScheduledItemWorkflowName})
+
+	var submitted SubmitPayload
+	env.RegisterActivityWithOptions(func(ctx context.Context, submit SubmitPayload) error { return nil }, activity.RegisterOptions{Name: SubmitItemActivityName})
+	env.OnActivity(SubmitItemActivityName, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		submitted = args.Get(1).(SubmitPayload)
+	}).Return(nil).Once()
+
+	scheduledTime := time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)
+	schedule := func(ctx workflow.Context, submit SubmitPayload) (string, error) {
+		ctx = workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: "schedule_daily",
+			TypedSearchAttributes: temporal.NewSearchAttributes(
+				scheduledByIDSearchAttribute.ValueSet("daily"),
+				scheduledStartTimeSearchAttribute.ValueSet(scheduledTime),
+			),
+		})
+		var itemID string
+		err := workflow.ExecuteChildWorkflow(ctx, ScheduledItemWorkflowName, submit).Get(ctx, &itemID)
+		return itemID, err
+	}
+
+	template := "{{.ScheduleID}}-report-{{.Date}}"
+	env.ExecuteWorkflow(schedule, SubmitPayload{ID: template, WorkflowName: ItemWorkflowAName, Item: ItemA{BasicItem: BasicItem{Id: template}}})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var itemID string
+	require.NoError(t, env.GetWorkflowResult(&itemID))
+	require.Equal(t, "daily-report-2026-10-18", itemID)
+	require.Equal(t, "daily-report-2026-10-18", submitted.ID)
+	require.Equal(t, ItemWorkflowAName, submitted.WorkflowName)
+	require.Equal(t, "daily-report-2026-10-18", submitted.Item.(map[string]interface{})["id"])
+}
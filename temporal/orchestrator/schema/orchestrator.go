@@ -0,0 +1,149 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"my-samples-go/temporal/orchestrator"
+)
+
+//go:generate go run ./schemagen -out schemas
+
+// generator knows the enums of the orchestrator types. The item status may be empty,
+// clients leave it to the item workflow to set the initial status.
+var generator = Generator{Enums: map[reflect.Type][]string{
+	reflect.TypeOf(orchestrator.ItemStatus("")): itemStatuses(),
+}}
+
+func itemStatuses() []string {
+	values := []string{""}
+	for _, status := range orchestrator.ItemStatuses {
+		values = append(values, status.String())
+	}
+	return values
+}
+
+// payloadTypes maps the signals carrying a payload to the payload type. The other signals have no payload.
+var payloadTypes = map[orchestrator.SignalType]reflect.Type{
+	orchestrator.RegisterSignal:        reflect.TypeOf(orchestrator.RegisterPayload{}),
+	orchestrator.DeregisterSignal:      reflect.TypeOf(orchestrator.DeregisterPayload{}),
+	orchestrator.StartProcessingSignal: reflect.TypeOf(orchestrator.StartProcessingPayload{}),
+	orchestrator.StopProcessingSignal:  reflect.TypeOf(orchestrator.StopProcessingPayload{}),
+	orchestrator.UpdateSignal:          reflect.TypeOf(orchestrator.UpdatePayload{}),
+	orchestrator.SubmitSignal:          reflect.TypeOf(orchestrator.SubmitPayload{}),
+	orchestrator.CancelSignal:          reflect.TypeOf(orchestrator.CancelPayload{}),
+	orchestrator.ConfigSignal:          reflect.TypeOf(orchestrator.ConfigPayload{}),
+}
+
+// File is a schema with the name of the file it is written to.
+type File struct {
+	Name   string
+	Schema *Schema
+}
+
+// Files returns the schemas of the basic item, of every registered item type and of every signal payload, sorted by name.
+func Files() ([]File, error) {
+	var files []File
+	add := func(name string, title string, t reflect.Type) error {
+		s, err := generator.Generate(title, t)
+		if err != nil {
+			return err
+		}
+		files = append(files, File{Name: name + ".schema.json", Schema: s})
+		return nil
+	}
+
+	if err := add("basic-item", "BasicItem", reflect.TypeOf(orchestrator.BasicItem{})); err != nil {
+		return nil, err
+	}
+	for _, name := range orchestrator.ItemTypes.Names() {
+		t, _ := orchestrator.ItemTypes.Lookup(name)
+		if err := add("item-"+strings.ToLower(t.Name), itemType(t).Name(), itemType(t)); err != nil {
+			return nil, err
+		}
+	}
+	for signalType, payloadType := range payloadTypes {
+		if err := add(string(signalType)+"-payload", payloadType.Name(), payloadType); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, nil
+}
+
+// itemType returns the Go type of the items built by t.
+func itemType(t orchestrator.ItemType) reflect.Type {
+	typ := reflect.TypeOf(t.NewItem("", "", nil))
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// ItemSchema returns the schema of the items processed by the item workflow registered as workflowName.
+func ItemSchema(workflowName string) (*Schema, bool) {
+	t, known := orchestrator.ItemTypes.LookupWorkflow(workflowName)
+	if !known {
+		return nil, false
+	}
+	s, err := generator.Generate(itemType(t).Name(), itemType(t))
+	if err != nil {
+		panic(err) // item types are declared in code, so this is a programming error
+	}
+	return s, true
+}
+
+// PayloadSchema returns the schema of the payload of signals of type signalType, false if they have no payload.
+func PayloadSchema(signalType orchestrator.SignalType) (*Schema, bool) {
+	t, hasPayload := payloadTypes[signalType]
+	if !hasPayload {
+		return nil, false
+	}
+	s, err := generator.Generate(t.Name(), t)
+	if err != nil {
+		panic(err)
+	}
+	return s, true
+}
+
+// ValidateItem checks item against the schema of the item workflow registered as workflowName.
+// Items of unknown workflows are not checked.
+func ValidateItem(workflowName string, item interface{}) error {
+	s, known := ItemSchema(workflowName)
+	if !known {
+		return nil
+	}
+	if err := s.Validate(item); err != nil {
+		return fmt.Errorf("item of %s: %w", workflowName, err)
+	}
+	return nil
+}
+
+// ValidateSignal checks the payload of sig against the schema of its type, including the item
+// it carries for the workflow it names. Signals of unknown types are not checked.
+func ValidateSignal(sig orchestrator.Signal) error {
+	s, hasPayload := PayloadSchema(sig.Type)
+	if !hasPayload {
+		return nil
+	}
+	if err := s.Validate(sig.Payload); err != nil {
+		return fmt.Errorf("%s signal: %w", sig.Type, err)
+	}
+
+	var p struct {
+		WorkflowName string      `json:"workflowName"`
+		Item         interface{} `json:"item"`
+	}
+	if err := orchestrator.ConvertPayload(sig.Payload, &p); err != nil {
+		return fmt.Errorf("%s signal: %w", sig.Type, err)
+	}
+	if p.Item == nil {
+		return nil
+	}
+	if err := ValidateItem(p.WorkflowName, p.Item); err != nil {
+		return fmt.Errorf("%s signal: %w", sig.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Package schema generates JSON Schemas for the orchestrator payloads from their Go types, and validates
+// payloads against them.
+//
+// Only the subset of JSON Schema needed by the orchestrator types is supported. Properties are taken from the
+// json tags, and structs do not allow additional properties, so misspelled fields are rejected.
+// Fields tagged jsonschema:"required" must be present, and must not be empty strings.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect of the generated schemas.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// Marshal returns the indented JSON form of s, as written to the schema files.
+func Marshal(s *Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Types lists the JSON types a value may have, empty for any type.
+// It is marshalled as a string when it has a single type.
+type Types []string
+
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+func (t Types) has(name string) bool {
+	for _, typ := range t {
+		if typ == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Generator builds schemas from Go types.
+type Generator struct {
+	// Enums holds the allowed values of string types, e.g. the item statuses.
+	Enums map[reflect.Type][]string
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Generate returns the schema of values of type t, titled title.
+func (g Generator) Generate(title string, t reflect.Type) (*Schema, error) {
+	s, err := g.generate(t)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", title, err)
+	}
+	s.Schema = Draft
+	s.Title = title
+	return s, nil
+}
+
+func (g Generator) generate(t reflect.Type) (*Schema, error) {
+	if values, isEnum := g.Enums[t]; isEnum {
+		return &Schema{Type: Types{"string"}, Enum: values}, nil
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}, nil
+	case durationType:
+		return &Schema{Type: Types{"integer"}}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: Types{"integer"}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}, nil
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Pointer:
+		s, err := g.generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if len(s.Type) > 0 && !s.Type.has("null") {
+			s.Type = append(s.Type, "null")
+		}
+		return s, nil
+	case reflect.Slice, reflect.Array:
+		items, err := g.generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: Types{"array", "null"}, Items: items}, nil
+	case reflect.Struct:
+		closed := false
+		s := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}, AdditionalProperties: &closed}
+		if err := g.addFields(s, t); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// addFields adds the properties of struct type t to s, flattening embedded structs like encoding/json does.
+func (g Generator) addFields(s *Schema, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitted := jsonName(field)
+		if omitted {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			if err := g.addFields(s, field.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		property, err := g.generate(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if isRequired(field) {
+			s.Required = append(s.Required, name)
+			if property.Type.has("string") && property.Enum == nil {
+				minLength := 1
+				property.MinLength = &minLength
+			}
+		}
+		s.Properties[name] = property
+	}
+	return nil
+}
+
+// jsonName returns the name of field in its json tag, and whether encoding/json ignores the field.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, option := range strings.Split(field.Tag.Get("jsonschema"), ",") {
+		if option == "required" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"my-samples-go/temporal/orchestrator"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Files_UpToDate(t *testing.T) {
+	files, err := Files()
+	require.NoError(t, err)
+
+	committed, err := filepath.Glob(filepath.Join("schemas", "*.schema.json"))
+	require.NoError(t, err)
+	require.Len(t, committed, len(files), "run go generate in the schema package")
+
+	for _, file := range files {
+		data, err := Marshal(file.Schema)
+		require.NoError(t, err)
+		want, err := os.ReadFile(filepath.Join("schemas", file.Name))
+		require.NoError(t, err, "run go generate in the schema package")
+		require.Equal(t, string(want), string(data), "%s is out of date, run go generate in the schema package", file.Name)
+	}
+}
+
+func Test_ValidateItem(t *testing.T) {
+	valid := orchestrator.ItemTypeA.NewItem("item-1", "", map[string]string{"extra-a": "a"})
+	require.NoError(t, ValidateItem(orchestrator.ItemWorkflowAName, valid))
+	require.NoError(t, ValidateItem(orchestrator.ItemWorkflowAName, map[string]interface{}{"id": "item-1", "status": "Completed"}))
+	require.NoError(t, ValidateItem("UnknownWorkflow", map[string]interface{}{"anything": 1}))
+
+	for name, item := range map[string]interface{}{
+		"empty id":           map[string]interface{}{"id": ""},
+		"missing id":         map[string]interface{}{"name": "item"},
+		"misspelled field":   map[string]interface{}{"id": "item-1", "extraFieldB": "b"},
+		"wrong type":         map[string]interface{}{"id": 1},
+		"unknown status":     map[string]interface{}{"id": "item-1", "status": "Done"},
+		"not an object":      "item-1",
+		"empty typed item":   orchestrator.ItemTypeA.NewItem("", "name", nil),
+		"item of wrong type": orchestrator.ItemTypeB.NewItem("item-1", "", map[string]string{"extra-b": "b"}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, ValidateItem(orchestrator.ItemWorkflowAName, item), ErrInvalid)
+		})
+	}
+}
+
+func Test_ValidateSignal(t *testing.T) {
+	report := orchestrator.NewCompletionReport("Finished Successfully", nil, time.Now(), time.Now())
+	for _, sig := range []orchestrator.Signal{
+		{Type: orchestrator.PingSignal},
+		{Type: orchestrator.DeregisterSignal, Payload: orchestrator.DeregisterPayload{ID: "item-1", Report: report}},
+		{Type: orchestrator.ConfigSignal, Payload: orchestrator.ConfigPayload{IdleTimeout: time.Minute}},
+		{Type: orchestrator.SubmitSignal, Payload: orchestrator.SubmitPayload{
+			ID: "item-1", WorkflowName: orchestrator.ItemWorkflowBName, Item: orchestrator.ItemTypeB.NewItem("item-1", "", nil),
+		}},
+	} {
+		require.NoError(t, ValidateSignal(sig), sig.Type)
+	}
+
+	err := ValidateSignal(orchestrator.Signal{Type: orchestrator.StartProcessingSignal, Payload: map[string]interface{}{"ID": "item-1"}})
+	require.ErrorIs(t, err, ErrInvalid)
+	require.ErrorContains(t, err, `missing required property "id"`)
+	require.ErrorContains(t, err, `unknown property "ID"`)
+
+	err = ValidateSignal(orchestrator.Signal{Type: orchestrator.DeregisterSignal, Payload: map[string]interface{}{
+		"id": "item-1", "report": map[string]interface{}{"startedAt": "yesterday"},
+	}})
+	require.ErrorContains(t, err, `report.startedAt: "yesterday" is not a date-time`)
+
+	err = ValidateSignal(orchestrator.Signal{Type: orchestrator.RegisterSignal, Payload: orchestrator.RegisterPayload{
+		ID: "item-1", ItemWorkflowID: "wf", ItemWorkflowRunID: "run", WorkflowName: orchestrator.ItemWorkflowAName,
+		Item: map[string]interface{}{"id": "item-1", "extra": "x"},
+	}})
+	require.ErrorContains(t, err, `item of ItemWorkflowA: invalid payload: (root): unknown property "extra"`)
+
+	require.ErrorIs(t, ValidateSignal(orchestrator.Signal{Type: orchestrator.CancelSignal}), ErrInvalid)
+}
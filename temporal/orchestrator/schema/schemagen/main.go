@@ -0,0 +1,34 @@
+// schemagen writes the JSON Schemas of the item and signal payload types, so clients in other languages
+// can validate their payloads before signalling the orchestrator. Run it with go generate in the schema package.
+package main
+
+import (
+	"flag"
+	"log"
+	"my-samples-go/temporal/orchestrator/schema"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	out := flag.String("out", "schemas", "directory the schema files are written to")
+	flag.Parse()
+
+	files, err := schema.Files()
+	if err != nil {
+		log.Fatalln("Unable to generate schemas", err)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatalln("Unable to create output directory", err)
+	}
+	for _, file := range files {
+		data, err := schema.Marshal(file.Schema)
+		if err != nil {
+			log.Fatalln("Unable to marshal schema", file.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(*out, file.Name), data, 0o644); err != nil {
+			log.Fatalln("Unable to write schema", file.Name, err)
+		}
+		log.Println("Wrote", filepath.Join(*out, file.Name))
+	}
+}
@@ -0,0 +1,136 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrInvalid marks payloads that do not match their schema.
+var ErrInvalid = errors.New("invalid payload")
+
+// Validate checks value against s. value may be any Go value, it is validated in its JSON form.
+// The error lists every violation, and wraps ErrInvalid.
+func (s *Schema) Validate(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return s.ValidateJSON(data)
+}
+
+// ValidateJSON checks the JSON document data against s.
+func (s *Schema) ValidateJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	var problems []string
+	s.validate("", value, &problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalid, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (s *Schema) validate(path string, value interface{}, problems *[]string) {
+	report := func(format string, args ...interface{}) {
+		location := path
+		if location == "" {
+			location = "(root)"
+		}
+		*problems = append(*problems, location+": "+fmt.Sprintf(format, args...))
+	}
+
+	if len(s.Type) > 0 && !s.Type.has(typeOf(value)) && !(s.Type.has("number") && typeOf(value) == "integer") {
+		report("expected %s, got %s", strings.Join(s.Type, " or "), typeOf(value))
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if s.Enum != nil && !contains(s.Enum, v) {
+			report("%q is not one of %q", v, s.Enum)
+		}
+		if s.MinLength != nil && utf8.RuneCountInString(v) < *s.MinLength {
+			report("must not be empty")
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				report("%q is not a date-time", v)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, present := v[name]; !present {
+				report("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, known := s.Properties[name]
+			if !known {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					report("unknown property %q", name)
+				}
+				continue
+			}
+			property.validate(join(path, name), v[name], problems)
+		}
+	}
+}
+
+// typeOf returns the JSON type of a value decoded with json.Decoder.UseNumber.
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func join(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package orchestrator
+
+import (
+	"go.temporal.io/sdk/temporal"
+)
+
+// Custom search attributes upserted by the item workflows, so item workflows can be listed with visibility queries,
+// also after the orchestrator has pruned them from its state. They must be registered on the namespace.
+var (
+	ItemIDSearchAttribute               = temporal.NewSearchAttributeKeyKeyword("ItemID")
+	ItemTypeSearchAttribute             = temporal.NewSearchAttributeKeyKeyword("ItemType")
+	ItemStatusSearchAttribute           = temporal.NewSearchAttributeKeyKeyword("ItemStatus")
+	OrchestratorDecisionSearchAttribute = temporal.NewSearchAttributeKeyKeyword("OrchestratorDecision")
+)
+
+// SearchAttributes lists the custom search attributes of the item workflows.
+var SearchAttributes = []temporal.SearchAttributeKey{
+	ItemIDSearchAttribute,
+	ItemTypeSearchAttribute,
+	ItemStatusSearchAttribute,
+	OrchestratorDecisionSearchAttribute,
+}
+
+// OrchestratorDecision is the last decision of the orchestrator about an item workflow.
+type OrchestratorDecision string
+
+const (
+	DecisionPending    OrchestratorDecision = "Pending"    // waiting for the orchestrator to accept the registration
+	DecisionRegistered OrchestratorDecision = "Registered" // registration accepted
+	DecisionHalted     OrchestratorDecision = "Halted"     // registration rejected
+	DecisionApproved   OrchestratorDecision = "Approved"   // processing permitted
+	DecisionDenied     OrchestratorDecision = "Denied"     // processing denied
+	DecisionLaunched   OrchestratorDecision = "Launched"   // launched by the orchestrator as a child workflow (pull model)
+)
+
+func (d OrchestratorDecision) String() string {
+	return string(d)
+}
+
+// OrchestratorDecisions lists all decisions.
+var OrchestratorDecisions = []OrchestratorDecision{
+	DecisionPending, DecisionRegistered, DecisionHalted, DecisionApproved, DecisionDenied, DecisionLaunched,
+}
@@ -1,5 +1,7 @@
 package orchestrator
 
+import "time"
+
 // SignalType represents predefined signal types for the orchestrator workflow
 type SignalType string
 
@@ -12,6 +14,12 @@ const (
 	StartProcessingSignal SignalType = "start-processing" // request permission to start processing
 	StopProcessingSignal  SignalType = "stop-processing"  // stop processing
 	UpdateSignal          SignalType = "update"           // update item
+	SubmitSignal          SignalType = "submit"           // submit item for the orchestrator to launch as a child workflow
+	CancelSignal          SignalType = "cancel"           // remove a submitted item from the queue
+	PauseSignal           SignalType = "pause"            // stop granting start-processing requests and launching items
+	ResumeSignal          SignalType = "resume"           // undo pause and drain
+	DrainSignal           SignalType = "drain"            // stop accepting items, finish once none are left
+	ConfigSignal          SignalType = "config"           // change the orchestrator configuration
 	PingSignal            SignalType = "ping"             // optional, for illustrative purpose of "start-and-signal-workflow"
 )
 
@@ -28,27 +36,45 @@ type ItemInstructionSignal struct {
 	Reason  string `json:"reason"`
 }
 
-// Example payload implementations
+// Example payload implementations.
+// Fields tagged jsonschema:"required" must be present and, for strings, non-empty, see the schema package.
 type RegisterPayload struct {
-	ID                string      `json:"id"`
-	ItemWorkflowID    string      `json:"itemWorkflowId"`
-	ItemWorkflowRunID string      `json:"itemWorkflowRunId"`
+	ID                string      `json:"id" jsonschema:"required"`
+	ItemWorkflowID    string      `json:"itemWorkflowId" jsonschema:"required"`
+	ItemWorkflowRunID string      `json:"itemWorkflowRunId" jsonschema:"required"`
+	WorkflowName      string      `json:"workflowName,omitempty"`
 	Item              interface{} `json:"item,omitempty"`
 }
 
 type DeregisterPayload struct {
-	ID string `json:"id"`
+	ID     string            `json:"id" jsonschema:"required"`
+	Report *CompletionReport `json:"report,omitempty"`
 }
 
 type StartProcessingPayload struct {
-	ID string `json:"id"`
+	ID string `json:"id" jsonschema:"required"`
 }
 
 type StopProcessingPayload struct {
-	ID string `json:"id"`
+	ID string `json:"id" jsonschema:"required"`
 }
 
 type UpdatePayload struct {
-	ID   string      `json:"id"`
+	ID   string      `json:"id" jsonschema:"required"`
 	Item interface{} `json:"item,omitempty"`
 }
+
+type SubmitPayload struct {
+	ID           string      `json:"id" jsonschema:"required"`
+	WorkflowName string      `json:"workflowName" jsonschema:"required"`
+	Item         interface{} `json:"item,omitempty"`
+}
+
+type CancelPayload struct {
+	ID string `json:"id" jsonschema:"required"`
+}
+
+type ConfigPayload struct {
+	IdleTimeout time.Duration `json:"idleTimeout"`         // 0 restores the default idle timeout
+	Retention   time.Duration `json:"retention,omitempty"` // 0 keeps deregistered items
+}
@@ -0,0 +1,131 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// SnapshotVersion is the version of the snapshot file format written by WriteSnapshot.
+// Bump it when the format changes incompatibly and keep ReadSnapshot able to read the older versions.
+const SnapshotVersion = 1
+
+var (
+	unsupportedSnapshotVersionError = errors.New("unsupported snapshot version")
+	inconsistentSnapshotError       = errors.New("inconsistent snapshot")
+)
+
+// Snapshot is an exported orchestrator state, used to back up an orchestrator and to seed a new one,
+// e.g. after a disaster or in another namespace.
+type Snapshot struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"createdAt"`
+	State     OrchestratorState `json:"state"`
+}
+
+func NewSnapshot(state OrchestratorState, createdAt time.Time) *Snapshot {
+	return &Snapshot{Version: SnapshotVersion, CreatedAt: createdAt, State: state}
+}
+
+// WriteSnapshot writes the snapshot as indented JSON.
+func WriteSnapshot(w io.Writer, snapshot *Snapshot) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot)
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot and validates it.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("unable to decode snapshot: %w", err)
+	}
+	if err := snapshot.Validate(); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Validate checks the version and that the state is one the orchestrator can continue from:
+// items are stored under their IDs, at most one item is in progress, registered items have a workflow,
+// queued items are unique, not registered and of a known type, and pruned items name their item workflow run
+// at most once.
+func (s *Snapshot) Validate() error {
+	if s.Version != SnapshotVersion {
+		return fmt.Errorf("%w %d, expected %d", unsupportedSnapshotVersionError, s.Version, SnapshotVersion)
+	}
+
+	var problems []error
+	state := s.State
+	if state.SignalsHandled < 0 {
+		problems = append(problems, fmt.Errorf("negative signals handled %d", state.SignalsHandled))
+	}
+	if state.Config.IdleTimeout < 0 {
+		problems = append(problems, fmt.Errorf("negative idle timeout %s", state.Config.IdleTimeout))
+	}
+	if state.Config.Retention < 0 {
+		problems = append(problems, fmt.Errorf("negative retention %s", state.Config.Retention))
+	}
+
+	ids := make([]string, 0, len(state.OrchestratedItems))
+	for id := range state.OrchestratedItems {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	inProgress := ""
+	for _, id := range ids {
+		item := state.OrchestratedItems[id]
+		switch {
+		case id == "" || item.ID != id:
+			problems = append(problems, fmt.Errorf("item %q is stored under ID %q", item.ID, id))
+		case item.InProgress && item.Deregistered:
+			problems = append(problems, fmt.Errorf("item %s is in progress and deregistered", id))
+		case item.InProgress && inProgress != "":
+			problems = append(problems, fmt.Errorf("items %s and %s are both in progress", inProgress, id))
+		case !item.Deregistered && item.ItemWorkflowID == "":
+			problems = append(problems, fmt.Errorf("registered item %s has no item workflow ID", id))
+		}
+		if item.InProgress && inProgress == "" {
+			inProgress = id
+		}
+	}
+
+	queued := make(map[string]bool, len(state.QueuedItems))
+	for i, item := range state.QueuedItems {
+		_, registered := state.OrchestratedItems[item.ID]
+		_, known := ItemTypes.LookupWorkflow(item.WorkflowName)
+		switch {
+		case item.ID == "":
+			problems = append(problems, fmt.Errorf("queued item %d has no ID", i))
+		case registered || queued[item.ID]:
+			problems = append(problems, fmt.Errorf("queued item %s is already queued or registered", item.ID))
+		case !known:
+			problems = append(problems, fmt.Errorf("queued item %s has the unknown workflow %q", item.ID, item.WorkflowName))
+		}
+		queued[item.ID] = true
+	}
+
+	pruned := make(map[string]bool, len(state.PrunedItems))
+	for i, item := range state.PrunedItems {
+		run := item.ItemWorkflowID + "/" + item.ItemWorkflowRunID
+		switch {
+		case item.ID == "":
+			problems = append(problems, fmt.Errorf("pruned item %d has no ID", i))
+		case item.ItemWorkflowID == "" && item.ItemWorkflowRunID != "":
+			problems = append(problems, fmt.Errorf("pruned item %s has a run ID but no item workflow ID", item.ID))
+		case item.ItemWorkflowID != "" && pruned[run]:
+			problems = append(problems, fmt.Errorf("pruned item %s is pruned twice", item.ID))
+		case item.FinishedAt.IsZero():
+			problems = append(problems, fmt.Errorf("pruned item %s has no finish time", item.ID))
+		}
+		pruned[run] = true
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %w", inconsistentSnapshotError, errors.Join(problems...))
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package orchestrator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Snapshot_RoundTrip(t *testing.T) {
+	items, queued := testItems()
+	state := OrchestratorState{SignalsHandled: 42, OrchestratedItems: items, QueuedItems: queued, Config: OrchestratorConfig{Paused: true},
+		PrunedItems: []PrunedItem{{ID: "item-0", ItemWorkflowID: "item_item-0", ItemWorkflowRunID: "run", FinishedAt: time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC)}}}
+	for id, item := range state.OrchestratedItems {
+		item.ItemWorkflowID = "item_" + id
+		state.OrchestratedItems[id] = item
+	}
+	snapshot := NewSnapshot(state, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSnapshot(&buf, snapshot))
+	read, err := ReadSnapshot(&buf)
+	require.NoError(t, err)
+	require.Equal(t, snapshot, read)
+}
+
+func Test_Snapshot_Validate(t *testing.T) {
+	_, err := ReadSnapshot(strings.NewReader(`{"version": 2}`))
+	require.ErrorIs(t, err, unsupportedSnapshotVersionError)
+
+	snapshot := NewSnapshot(OrchestratorState{
+		OrchestratedItems: map[string]OrchestratedItem{
+			"item-1": {ID: "item-1", ItemWorkflowID: "item_item-1", InProgress: true},
+			"item-2": {ID: "item-2", ItemWorkflowID: "item_item-2", InProgress: true},
+			"item-3": {ID: "item-3"},
+			"item-4": {ID: "item-x", Deregistered: true},
+		},
+		QueuedItems: []QueuedItem{
+			{ID: "item-1", WorkflowName: ItemWorkflowAName},
+			{ID: "item-5", WorkflowName: "ItemWorkflowC"},
+		},
+		PrunedItems: []PrunedItem{
+			{ItemWorkflowID: "item_item-6", FinishedAt: time.Now()},
+			{ID: "item-7", ItemWorkflowRunID: "run", FinishedAt: time.Now()},
+			{ID: "item-8", ItemWorkflowID: "item_item-8", ItemWorkflowRunID: "run"},
+			{ID: "item-9", ItemWorkflowID: "item_item-9", ItemWorkflowRunID: "run", FinishedAt: time.Now()},
+			{ID: "item-9", ItemWorkflowID: "item_item-9", ItemWorkflowRunID: "run", FinishedAt: time.Now()},
+		},
+	}, time.Now())
+	err = snapshot.Validate()
+	require.ErrorIs(t, err, inconsistentSnapshotError)
+	for _, problem := range []string{
+		"items item-1 and item-2 are both in progress",
+		"registered item item-3 has no item workflow ID",
+		`item "item-x" is stored under ID "item-4"`,
+		"queued item item-1 is already queued or registered",
+		`queued item item-5 has the unknown workflow "ItemWorkflowC"`,
+		"pruned item 0 has no ID",
+		"pruned item item-7 has a run ID but no item workflow ID",
+		"pruned item item-8 has no finish time",
+		"pruned item item-9 is pruned twice",
+	} {
+		require.ErrorContains(t, err, problem)
+	}
+
+	require.NoError(t, NewSnapshot(OrchestratorState{}, time.Now()).Validate())
+}
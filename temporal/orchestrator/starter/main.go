@@ -2,110 +2,159 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/logging"
 	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
-	"github.com/google/uuid"
 	"go.temporal.io/sdk/client"
 )
 
 func main() {
-	ctx := context.Background()
+	// The workflows started by this run log the same request ID as the starter.
+	ctx := logging.WithRequestID(context.Background(), logging.NewRequestID())
 
-	if len(os.Args) < 3 {
-		log.Fatalln("An item type and ID must be provided")
+	submit := flag.Bool("submit", false, "submit the item to the orchestrator, which launches it as a child workflow when capacity allows")
+	detach := flag.Bool("detach", false, "print the workflow ID and run ID and exit instead of waiting for the item workflow, see 'orchestratorctl result'")
+	bulkFile := flag.String("file", "", "bulk mode: read the items from a .csv or .jsonl file instead of the command line")
+	reportFile := flag.String("report", "bulk-report.csv", "bulk mode: .csv or .jsonl file the outcome of each item is written to")
+	parallelism := flag.Int("parallelism", 0, fmt.Sprintf("bulk mode: number of items handled at the same time, %d with -submit and 1 without if zero", orchestratorclient.DefaultBulkParallelism))
+	timeout := flag.Duration("timeout", time.Hour, "bulk mode: how long to wait for the outcome of each item, no limit if zero")
+	fieldValues := make(map[string]func() map[string]string)
+	for _, itemType := range orchestrator.ItemTypes.Types() {
+		fieldValues[itemType.Name] = itemType.RegisterFlags(flag.CommandLine)
 	}
-	itemType := os.Args[1]
-	itemID := os.Args[2]
+	cfg := config.New(orchestrator.TaskQueueName)
+	cfg.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	if err := cfg.Load(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	cfg.SetDefaultLogger()
+	shutdownTracing, err := cfg.StartTracing(ctx, "orchestrator-starter")
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	defer shutdownTracing(ctx)
 
-	c, err := client.Dial(client.Options{
-		HostPort: "passthrough:///localhost:7233",
-	})
+	if *bulkFile != "" {
+		if *detach {
+			logging.Fatal("-detach is not supported in bulk mode")
+		}
+		runBulk(ctx, cfg, *bulkFile, *reportFile, orchestratorclient.BulkOptions{Parallelism: *parallelism, Submit: *submit, Timeout: *timeout})
+		return
+	}
+
+	if flag.NArg() < 2 {
+		logging.Fatal(fmt.Sprintf("An item type (%s) and ID must be provided", strings.Join(orchestrator.ItemTypes.Names(), ", ")))
+	}
+	itemTypeName := flag.Arg(0)
+	itemID := flag.Arg(1)
+
+	itemType, err := orchestrator.ItemTypes.Lookup(itemTypeName)
 	if err != nil {
-		log.Fatalln("Unable to create Temporal client", err)
+		logging.Fatal("Unable to create item", "error", err)
 	}
+	item := itemType.NewItem(itemID, "", fieldValues[itemType.Name]())
+
+	c := dial(cfg)
 	defer c.Close()
 
-	// Ensure the orchestrator is running
-	ensureOrchestratorRunning(ctx, c)
-
-	// Start the ItemWorkflow
-	workflowID := "item_" + itemID + "_" + uuid.New().String()
-	options := client.StartWorkflowOptions{
-		ID:        workflowID,
-		TaskQueue: "orchestrator-task-queue",
-	}
-
-	var item orchestrator.Item
-	var workflowName string
-	switch itemType {
-	case "a", "A":
-		workflowName = orchestrator.ItemWorkflowAName
-		item = &orchestrator.ItemA{
-			BasicItem: orchestrator.BasicItem{
-				Id:   itemID,
-				Name: fmt.Sprintf("Item-A-%s", itemID),
-			},
-			ExtraFieldA: "Extra data for Item A",
-		}
-	case "b", "B":
-		workflowName = orchestrator.ItemWorkflowBName
-		item = &orchestrator.ItemB{
-			BasicItem: orchestrator.BasicItem{
-				Id:   itemID,
-				Name: fmt.Sprintf("Item-B-%s", itemID),
-			},
-			ExtraFieldB: "Extra data for Item B",
-		}
-	default:
-		workflowName = orchestrator.ItemWorkflowAName // Default to A if unknown type
-		item = &orchestrator.BasicItem{
-			Id:     itemID,
-			Name:   fmt.Sprintf("Item-%s", itemID),
-			Status: "New",
+	oc := orchestratorclient.New(c, cfg.TaskQueue)
+
+	if *submit {
+		// The orchestrator owns the item's lifecycle from here on, there is no result to wait for.
+		slog.InfoContext(ctx, "Submitting item to the orchestrator", logging.ItemIDKey, itemID, logging.ItemTypeKey, itemType.Name)
+		if err := oc.SubmitItem(ctx, itemType, item); err != nil {
+			logging.Fatal("Unable to submit item to orchestrator workflow", "error", err)
 		}
+		fmt.Printf("Item '%s' submitted to the orchestrator\n", itemID)
+		return
 	}
 
-	log.Printf("Starting ItemWorkflow with ID '%s' for item '%v'\n", workflowID, item)
-	we, err := c.ExecuteWorkflow(ctx, options, workflowName, item)
+	// Start the ItemWorkflow, making sure the orchestrator is running first.
+	slog.InfoContext(ctx, "Starting item workflow", logging.ItemIDKey, itemID, logging.ItemTypeKey, itemType.Name)
+	we, err := oc.StartItem(ctx, itemType, item)
 	if err != nil {
-		log.Fatalln("Unable to execute workflow", err)
+		logging.Fatal("Unable to execute workflow", "error", err)
 	}
 
-	log.Printf("Workflow started. WorkflowID: %s, RunID: %s\n", we.GetID(), we.GetRunID())
+	slog.InfoContext(ctx, "Workflow started", logging.ItemIDKey, itemID, logging.ItemWorkflowIDKey, we.GetID(), "runID", we.GetRunID())
+	if *detach {
+		fmt.Printf("Item '%s' started. WorkflowID: %s, RunID: %s\n", itemID, we.GetID(), we.GetRunID())
+		return
+	}
 
 	// Wait for the workflow to complete and print the result.
 	var result string
 	err = we.Get(ctx, &result)
 	if err != nil {
-		log.Fatalln("Workflow failed:", err)
+		logging.Fatal("Workflow failed", "error", err)
 	}
 	fmt.Printf("Workflow for item '%s' completed with result: %s\n", itemID, result)
 }
 
-func ensureOrchestratorRunning(ctx context.Context, c client.Client) {
-	// Use SignalWithStart to start the workflow if it's not running, or signal it if it is.
-	// This is a more robust way to ensure the singleton is running and ready.
-	options := client.StartWorkflowOptions{
-		ID:        orchestrator.OrchestratorWorkflowID,
-		TaskQueue: "orchestrator-task-queue",
-	}
-
-	// Send a benign signal (e.g., "ping") to ensure the workflow is alive.
-	// If the workflow is not running, it will be started.
-	// The Get will block until the workflow is started and the first task is completed.
-	_, err := c.SignalWithStartWorkflow(ctx,
-		orchestrator.OrchestratorWorkflowID,
-		orchestrator.SignalChannelName,
-		orchestrator.Signal{Type: orchestrator.PingSignal},
-		options,
-		orchestrator.OrchestratorWorkflowName,
-		orchestrator.OrchestratorState{})
+func dial(cfg *config.Config) client.Client {
+	c, err := cfg.Dial()
+	if err != nil {
+		logging.Fatal("Unable to create Temporal client", "error", err)
+	}
+	return c
+}
+
+// runBulk starts or submits all items of a bulk file, waits for their outcome and writes the report.
+// An interrupt stops waiting, the report then holds the items finished so far.
+func runBulk(ctx context.Context, cfg *config.Config, bulkFile string, reportFile string, opts orchestratorclient.BulkOptions) {
+	inputFormat, err := orchestratorclient.BulkFormat(bulkFile)
 	if err != nil {
-		log.Fatalln("Unable to signal/start orchestrator workflow", err)
-	} else {
-		log.Println("Orchestrator workflow is running.")
+		logging.Fatal("Invalid bulk file", "error", err)
+	}
+	reportFormat, err := orchestratorclient.BulkFormat(reportFile)
+	if err != nil {
+		logging.Fatal("Invalid report file", "error", err)
+	}
+	in, err := os.Open(bulkFile)
+	if err != nil {
+		logging.Fatal("Unable to open bulk file", "error", err)
+	}
+	items, err := orchestratorclient.ReadBulkItems(in, inputFormat)
+	in.Close()
+	if err != nil {
+		logging.Fatal("Unable to read bulk file", "error", err)
+	}
+
+	c := dial(cfg)
+	defer c.Close()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if opts.Parallelism > 1 && !opts.Submit {
+		slog.WarnContext(ctx, "Items started at the same time are halted by the orchestrator while another one is processing, use -submit to queue them")
+	}
+	slog.InfoContext(ctx, "Processing bulk file", "items", len(items), "file", bulkFile, "parallelism", opts.Parallelism, "submit", opts.Submit)
+	results := orchestratorclient.New(c, cfg.TaskQueue).SubmitBulk(ctx, items, opts)
+
+	out, err := os.Create(reportFile)
+	if err != nil {
+		logging.Fatal("Unable to create report file", "error", err)
+	}
+	defer out.Close()
+	if err := orchestratorclient.WriteBulkReport(out, reportFormat, results); err != nil {
+		logging.Fatal("Unable to write report", "error", err)
+	}
+
+	counts := make(map[string]int)
+	for _, r := range results {
+		counts[r.Status]++
 	}
+	fmt.Printf("%d items: %d completed, %d failed, %d skipped. Report written to %s\n", len(results),
+		counts[orchestratorclient.BulkCompleted], counts[orchestratorclient.BulkFailed], counts[orchestratorclient.BulkSkipped], reportFile)
 }
@@ -1,11 +1,28 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"my-samples-go/temporal/claimcheck"
+	"my-samples-go/temporal/config"
+	"my-samples-go/temporal/health"
+	"my-samples-go/temporal/logging"
+	"my-samples-go/temporal/metrics"
 	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"my-samples-go/temporal/orchestrator/orchestratorpb"
+	"my-samples-go/temporal/orchestrator/schema"
+	"my-samples-go/temporal/tracing"
+	"net/http"
 	"time"
 
-	"go.temporal.io/sdk/client"
+	"github.com/google/uuid"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/worker"
 	"go.temporal.io/sdk/workflow"
 )
@@ -14,8 +31,24 @@ const (
 	// IdleTimeout is the maximum duration the orchestrator workflow will wait without receiving any signals
 	// before checking if it should continue (e.g. if there are still registered items to orchestrate) or finish.
 	IdleTimeout = 120 * time.Second
+
+	// ClaimCheckGracePeriod is subtracted from the time before which claim check blobs were collected by the runs
+	// before protocol version 4, to keep the blobs an item workflow was started with before it registered.
+	ClaimCheckGracePeriod = time.Hour
 )
 
+// errDraining is the error in the completion report of an item dropped from the queue by a Drain signal.
+var errDraining = errors.New("dropped from the queue, the orchestrator is draining")
+
+// childCompletion is sent on the orchestrator's internal channel when a launched child item workflow completes.
+type childCompletion struct {
+	ID         string
+	Result     string
+	Err        error
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
 type OW[O orchestrator.OrchestratorStateManager] struct {
 	workflowAlias          string
 	createStateManagerFunc orchestrator.CreateOrchestratorStateManagerFunc[O]
@@ -31,23 +64,35 @@ func NewOW[O orchestrator.OrchestratorStateManager](workflowAlias string, Create
 // OrchestratorWorkflow is the main workflow for the orchestrator.
 func (ow *OW[O]) OrchestratorWorkflow(ctx workflow.Context, state orchestrator.OrchestratorState) error {
 	signalCh := workflow.GetSignalChannel(ctx, orchestrator.SignalChannelName)
+	childDoneCh := workflow.NewChannel(ctx)
+	childrenInFlight := 0
 	logger := workflow.GetLogger(ctx)
 
+	// Runs started before the pull model existed never launch child workflows.
+	protocolVersion := workflow.GetVersion(ctx, orchestrator.OrchestratorProtocolChangeID, workflow.DefaultVersion, orchestrator.OrchestratorProtocolVersion)
+
 	stateManager := ow.createStateManagerFunc(&state)
 	logger.Info("Orchestrator workflow started", "signalsHandled", stateManager.GetState().GetSignalsHandled(), "orchestratedItems", len(stateManager.AllItems()))
+	orchestrator.RecordStateGauges(ctx, stateManager)
 
-	// Set up query handler
-	err := workflow.SetQueryHandler(ctx, orchestrator.QueryName, func() (orchestrator.QueryResponse, error) {
-		return ow.buildQueryResponse(stateManager), nil
-	})
-	if err != nil {
+	if err := ow.setQueryHandlers(ctx, stateManager); err != nil {
 		logger.Error("Failed to set query handler", "error", err)
 		return err
 	}
 
+	// The items pruned by the previous run finished before this one started, so their blobs can be deleted now.
+	if protocolVersion >= 6 && len(stateManager.GetState().PrunedItems) > 0 {
+		ow.deletePrunedBlobs(ctx, stateManager)
+	}
+
 	for {
+		// Launch submitted items as child workflows while the processing slot is free.
+		if protocolVersion >= 1 {
+			childrenInFlight += ow.launchQueuedItems(ctx, stateManager, childDoneCh, protocolVersion)
+		}
+
 		idleTimerCtx, cancelIdleTimer := workflow.WithCancel(ctx)
-		idleTimerFuture := workflow.NewTimer(idleTimerCtx, IdleTimeout)
+		idleTimerFuture := workflow.NewTimer(idleTimerCtx, ow.idleTimeout(stateManager))
 
 		selector := workflow.NewSelector(ctx)
 		selector.AddFuture(idleTimerFuture, func(f workflow.Future) {
@@ -55,25 +100,50 @@ func (ow *OW[O]) OrchestratorWorkflow(ctx workflow.Context, state orchestrator.O
 		})
 
 		var sig orchestrator.Signal
+		signalCtx := ctx
 		selector.AddReceive(signalCh, func(c workflow.ReceiveChannel, more bool) {
 			c.Receive(ctx, &sig)
+			// Handle the signal in the trace and request of its sender, so the replies belong to the item's trace.
+			signalCtx = logging.SignalContext(tracing.SignalContext(ctx, orchestrator.SignalChannelName), orchestrator.SignalChannelName)
 			cancelIdleTimer() // Cancel the timer because a signal was received.
 		})
 
-		selector.Select(ctx) // Wait for a signal or the timer.
+		var completion *childCompletion
+		selector.AddReceive(childDoneCh, func(c workflow.ReceiveChannel, more bool) {
+			var cc childCompletion
+			c.Receive(ctx, &cc)
+			completion = &cc
+			cancelIdleTimer() // Cancel the timer because a child workflow completed.
+		})
+
+		selector.Select(ctx) // Wait for a signal, a child completion or the timer.
 
 		if sig.Type != "" { // A signal was received
-			ow.HandleSignal(ctx, stateManager, sig)
+			ow.HandleSignal(signalCtx, stateManager, sig)
+		} else if completion != nil { // A child item workflow completed
+			childrenInFlight--
+			ow.handleChildCompletion(ctx, stateManager, *completion)
 		} else { // The timer fired
-			if len(stateManager.RegisteredItems()) == 0 {
+			if len(stateManager.RegisteredItems()) == 0 && len(stateManager.QueuedItems()) == 0 {
 				logger.Info("Timer fired and no registered items, finishing workflow.")
-				return nil
+				return ow.finish(ctx, stateManager, protocolVersion)
 			}
 			logger.Info("Timer fired, but registered items exist. Resetting timer.", "registeredCount", len(stateManager.RegisteredItems()))
 		}
 
+		if protocolVersion >= 2 {
+			ow.pruneItems(ctx, stateManager, protocolVersion)
+		}
+		orchestrator.RecordStateGauges(ctx, stateManager)
+
+		if stateManager.Config().Draining && len(stateManager.RegisteredItems()) == 0 && len(stateManager.QueuedItems()) == 0 && childrenInFlight == 0 {
+			logger.Info("Drained, finishing workflow.")
+			return ow.finish(ctx, stateManager, protocolVersion)
+		}
+
 		// Check for ContinueAsNew after processing the signal or timer.
-		if workflow.GetInfo(ctx).GetContinueAsNewSuggested() {
+		// Child item workflows are awaited by this run, so continuing as new is deferred until none are in flight.
+		if workflow.GetInfo(ctx).GetContinueAsNewSuggested() && childrenInFlight == 0 {
 			logger.Info("Continuing as new due to Temporal suggestion", "orchestratedItems", len(stateManager.AllItems()))
 			state := orchestrator.OrchestratorState{}
 			if stateManager.GetState() != nil {
@@ -85,9 +155,24 @@ func (ow *OW[O]) OrchestratorWorkflow(ctx workflow.Context, state orchestrator.O
 }
 
 func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestrator.Signal) {
+	if id := orchestrator.PayloadID(sig.Payload); id != "" {
+		// Every line logged for the signal names the item it is about.
+		ctx = logging.With(ctx, logging.ItemIDKey, id)
+	}
 	logger := workflow.GetLogger(ctx)
 
 	stateManager.GetState().SignalsHandled++
+	workflow.GetMetricsHandler(ctx).WithTags(map[string]string{orchestrator.SignalTypeTag: string(sig.Type)}).Counter(orchestrator.SignalsHandledMetric).Inc(1)
+
+	// Runs started before payloads were validated accept whatever ConvertPayload manages to unmarshal.
+	validate := workflow.GetVersion(ctx, orchestrator.OrchestratorProtocolChangeID, workflow.DefaultVersion, orchestrator.OrchestratorProtocolVersion) >= 3
+	if validate {
+		if err := schema.ValidateSignal(sig); err != nil {
+			ow.rejectSignal(ctx, sig, err)
+			return
+		}
+	}
+
 	switch sig.Type {
 	case orchestrator.RegisterSignal:
 		var p orchestrator.RegisterPayload
@@ -95,22 +180,23 @@ func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestr
 			logger.Error("Failed to convert register payload", "error", err)
 			return
 		}
-		logger.Info("Handling register signal", "id", p.ID)
+		logger.Info("Handling register signal")
 
 		canProceed := true
 		reason := "Registration accepted."
-		if err := stateManager.RegisterItem(p.ID, p.ItemWorkflowID, p.ItemWorkflowRunID, p.Item); err != nil {
+		if err := stateManager.RegisterItem(p.ID, p.ItemWorkflowID, p.ItemWorkflowRunID, p.WorkflowName, workflow.Now(ctx), p.Item); err != nil {
 			logger.Error("Failed to register item", "error", err)
 			canProceed = false
 			reason = "Registration denied: " + err.Error()
 		}
 
+		orchestrator.RecordDecision(ctx, sig.Type, canProceed)
 		itemSignal := orchestrator.ItemInstructionSignal{ID: p.ID, Proceed: canProceed, Reason: reason}
 
-		logger.Info("Sending signal to item workflow", "workflowID", p.ItemWorkflowID, "proceed", canProceed)
+		logger.Info("Sending signal to item workflow", logging.ItemWorkflowIDKey, p.ItemWorkflowID, "proceed", canProceed)
 		err := workflow.SignalExternalWorkflow(ctx, p.ItemWorkflowID, p.ItemWorkflowRunID, orchestrator.ItemSignalChannelName, itemSignal).Get(ctx, nil)
 		if err != nil {
-			logger.Error("Failed to send signal to item workflow", "error", err, "itemWorkflowID", p.ItemWorkflowID)
+			logger.Error("Failed to send signal to item workflow", "error", err, logging.ItemWorkflowIDKey, p.ItemWorkflowID)
 			return
 		}
 
@@ -120,7 +206,7 @@ func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestr
 			logger.Error("Failed to convert start-processing payload", "error", err)
 			return
 		}
-		logger.Info("Handling start-processing request", "id", p.ID)
+		logger.Info("Handling start-processing request")
 
 		canProceed := true
 		reason := "Start processing permitted."
@@ -135,12 +221,13 @@ func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestr
 			return
 		}
 
+		orchestrator.RecordDecision(ctx, sig.Type, canProceed)
 		itemSignal := orchestrator.ItemInstructionSignal{ID: p.ID, Proceed: canProceed, Reason: reason}
 
-		logger.Info("Sending signal to item workflow", "workflowID", item.ItemWorkflowID, "proceed", canProceed)
+		logger.Info("Sending signal to item workflow", logging.ItemWorkflowIDKey, item.ItemWorkflowID, "proceed", canProceed)
 		err = workflow.SignalExternalWorkflow(ctx, item.ItemWorkflowID, item.ItemWorkflowRunID, orchestrator.ItemSignalChannelName, itemSignal).Get(ctx, nil)
 		if err != nil {
-			logger.Error("Failed to send signal to item workflow", "error", err, "itemWorkflowID", item.ItemWorkflowID)
+			logger.Error("Failed to send signal to item workflow", "error", err, logging.ItemWorkflowIDKey, item.ItemWorkflowID)
 			return
 		}
 
@@ -150,7 +237,7 @@ func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestr
 			logger.Error("Failed to convert stop-processing payload", "error", err)
 			return
 		}
-		logger.Info("Handling stop-processing signal", "id", p.ID)
+		logger.Info("Handling stop-processing signal")
 
 		_, err := stateManager.StopProcessing(p.ID)
 		if err != nil {
@@ -164,9 +251,9 @@ func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestr
 			logger.Error("Failed to convert deregister payload", "error", err)
 			return
 		}
-		logger.Info("Handling de-register signal", "id", p.ID)
+		logger.Info("Handling de-register signal")
 
-		if err := stateManager.Deregister(p.ID); err != nil {
+		if err := stateManager.Deregister(p.ID, p.Report, workflow.Now(ctx)); err != nil {
 			logger.Error("Failed to stop de-register item", "error", err)
 			return
 		}
@@ -177,13 +264,84 @@ func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestr
 			logger.Error("Failed to convert update payload", "error", err)
 			return
 		}
-		logger.Info("Handling update signal", "id", p.ID)
+		logger.Info("Handling update signal")
+
+		if validate && p.Item != nil {
+			if err := schema.ValidateItem(stateManager.AllItems()[p.ID].WorkflowName, p.Item); err != nil {
+				logger.Error("Rejected invalid signal", "type", sig.Type, "error", err)
+				return
+			}
+		}
 
 		if err := stateManager.UpdateItem(p.ID, p.Item); err != nil {
 			logger.Error("Failed to update item", "error", err)
 			return
 		}
 
+	case orchestrator.SubmitSignal:
+		var p orchestrator.SubmitPayload
+		if err := orchestrator.ConvertPayload(sig.Payload, &p); err != nil {
+			logger.Error("Failed to convert submit payload", "error", err)
+			return
+		}
+		logger.Info("Handling submit signal", "workflowName", p.WorkflowName)
+
+		if _, known := orchestrator.ItemTypes.LookupWorkflow(p.WorkflowName); !known {
+			logger.Error("Failed to submit item", "error", "unknown item workflow", "workflowName", p.WorkflowName)
+			return
+		}
+
+		if err := stateManager.EnqueueItem(p.ID, p.WorkflowName, p.Item); err != nil {
+			logger.Error("Failed to submit item", "error", err)
+			return
+		}
+
+	case orchestrator.CancelSignal:
+		var p orchestrator.CancelPayload
+		if err := orchestrator.ConvertPayload(sig.Payload, &p); err != nil {
+			logger.Error("Failed to convert cancel payload", "error", err)
+			return
+		}
+		logger.Info("Handling cancel signal")
+
+		if err := stateManager.RemoveQueuedItem(p.ID); err != nil {
+			logger.Error("Failed to cancel queued item", "error", err)
+			return
+		}
+
+	case orchestrator.PauseSignal, orchestrator.ResumeSignal, orchestrator.DrainSignal:
+		logger.Info("Handling operator signal", "type", sig.Type)
+
+		config := stateManager.Config()
+		switch sig.Type {
+		case orchestrator.PauseSignal:
+			config.Paused = true
+		case orchestrator.ResumeSignal:
+			config.Paused = false
+			config.Draining = false
+		case orchestrator.DrainSignal:
+			config.Draining = true
+		}
+		stateManager.SetConfig(config)
+
+		// Runs before protocol version 5 launched the queued items once draining, all at the same time.
+		if sig.Type == orchestrator.DrainSignal && workflow.GetVersion(ctx, orchestrator.OrchestratorProtocolChangeID, workflow.DefaultVersion, orchestrator.OrchestratorProtocolVersion) >= 5 {
+			ow.dropQueuedItems(ctx, stateManager)
+		}
+
+	case orchestrator.ConfigSignal:
+		var p orchestrator.ConfigPayload
+		if err := orchestrator.ConvertPayload(sig.Payload, &p); err != nil {
+			logger.Error("Failed to convert config payload", "error", err)
+			return
+		}
+		logger.Info("Handling config signal", "idleTimeout", p.IdleTimeout)
+
+		config := stateManager.Config()
+		config.IdleTimeout = p.IdleTimeout
+		config.Retention = p.Retention
+		stateManager.SetConfig(config)
+
 	case orchestrator.PingSignal:
 		logger.Info("Handling ping signal")
 	default:
@@ -191,32 +349,393 @@ func (ow *OW[O]) HandleSignal(ctx workflow.Context, stateManager O, sig orchestr
 	}
 }
 
+// rejectSignal logs a signal whose payload does not match its schema. An item workflow whose registration
+// is rejected is denied, so it does not wait for an instruction that never comes.
+func (ow *OW[O]) rejectSignal(ctx workflow.Context, sig orchestrator.Signal, validationErr error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Error("Rejected invalid signal", "type", sig.Type, "error", validationErr)
+
+	var p orchestrator.RegisterPayload
+	if sig.Type != orchestrator.RegisterSignal || orchestrator.ConvertPayload(sig.Payload, &p) != nil || p.ItemWorkflowID == "" {
+		return
+	}
+	orchestrator.RecordDecision(ctx, sig.Type, false)
+	itemSignal := orchestrator.ItemInstructionSignal{ID: p.ID, Proceed: false, Reason: "Registration denied: " + validationErr.Error()}
+	err := workflow.SignalExternalWorkflow(ctx, p.ItemWorkflowID, p.ItemWorkflowRunID, orchestrator.ItemSignalChannelName, itemSignal).Get(ctx, nil)
+	if err != nil {
+		logger.Error("Failed to send signal to item workflow", "error", err, logging.ItemWorkflowIDKey, p.ItemWorkflowID)
+	}
+}
+
+// launchQueuedItems starts queued items as child workflows for as long as there is capacity,
+// and returns the number of children launched. Each child reports its completion on childDoneCh.
+func (ow *OW[O]) launchQueuedItems(ctx workflow.Context, stateManager O, childDoneCh workflow.Channel, protocolVersion workflow.Version) int {
+	logger := workflow.GetLogger(ctx)
+
+	launched := 0
+	for stateManager.HasCapacity() {
+		queued := stateManager.DequeueItem()
+		if queued == nil {
+			break
+		}
+
+		var itemWorkflowID string
+		err := workflow.SideEffect(ctx, func(ctx workflow.Context) interface{} {
+			return "item_" + queued.ID + "_" + uuid.New().String()
+		}).Get(&itemWorkflowID)
+		if err != nil {
+			logger.Error("Failed to generate item workflow ID", "error", err, logging.ItemIDKey, queued.ID)
+			ow.failLaunch(ctx, stateManager, *queued, itemWorkflowID, err)
+			continue
+		}
+
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID: itemWorkflowID,
+			// Let the item finish its work even if the orchestrator run closes.
+			ParentClosePolicy: enumspb.PARENT_CLOSE_POLICY_ABANDON,
+		})
+		childFuture := workflow.ExecuteChildWorkflow(childCtx, queued.WorkflowName, queued.Payload)
+
+		var childExecution workflow.Execution
+		if err := childFuture.GetChildWorkflowExecution().Get(ctx, &childExecution); err != nil {
+			logger.Error("Failed to start child item workflow", "error", err, logging.ItemIDKey, queued.ID, logging.ItemWorkflowIDKey, itemWorkflowID)
+			ow.failLaunch(ctx, stateManager, *queued, itemWorkflowID, err)
+			continue
+		}
+
+		registerErr := stateManager.RegisterItem(queued.ID, childExecution.ID, childExecution.RunID, queued.WorkflowName, workflow.Now(ctx), queued.Payload)
+		if registerErr != nil {
+			logger.Error("Failed to register launched item", "error", registerErr, logging.ItemIDKey, queued.ID)
+		}
+		// A denied registration is stored deregistered, which would leave the slot looking free while the
+		// child runs. Runs before protocol version 5 marked it in progress anyway and went on launching.
+		if registerErr == nil || protocolVersion < 5 {
+			if _, err := stateManager.StartProcessing(queued.ID); err != nil {
+				logger.Error("Failed to start processing launched item", "error", err, logging.ItemIDKey, queued.ID)
+			}
+		}
+		logger.Info("Launched child item workflow", logging.ItemIDKey, queued.ID, logging.ItemWorkflowIDKey, childExecution.ID)
+
+		itemID := queued.ID
+		startedAt := workflow.Now(ctx)
+		workflow.Go(ctx, func(ctx workflow.Context) {
+			var result string
+			err := childFuture.Get(ctx, &result)
+			childDoneCh.Send(ctx, childCompletion{ID: itemID, Result: result, Err: err, StartedAt: startedAt, FinishedAt: workflow.Now(ctx)})
+		})
+		launched++
+		if registerErr != nil && protocolVersion >= 5 {
+			break
+		}
+	}
+	return launched
+}
+
+// dropQueuedItems records every queued item as deregistered with a cancelled completion report. A draining
+// orchestrator launches no more items, and clients waiting for them learn that they were dropped.
+func (ow *OW[O]) dropQueuedItems(ctx workflow.Context, stateManager O) {
+	for queued := stateManager.DequeueItem(); queued != nil; queued = stateManager.DequeueItem() {
+		workflow.GetLogger(ctx).Info("Dropping queued item", logging.ItemIDKey, queued.ID)
+		ow.recordUnlaunched(ctx, stateManager, *queued, "", orchestrator.ItemStatusCancelled, errDraining)
+	}
+}
+
+// failLaunch records a queued item whose child workflow could not be started as deregistered, with a completion
+// report holding the error, so the item does not vanish and clients waiting for it learn how it ended.
+func (ow *OW[O]) failLaunch(ctx workflow.Context, stateManager O, queued orchestrator.QueuedItem, itemWorkflowID string, launchErr error) {
+	ow.recordUnlaunched(ctx, stateManager, queued, itemWorkflowID, orchestrator.ItemStatusFailed, launchErr)
+}
+
+// recordUnlaunched records a queued item that never ran as deregistered, with a completion report of the given status.
+func (ow *OW[O]) recordUnlaunched(ctx workflow.Context, stateManager O, queued orchestrator.QueuedItem, itemWorkflowID string, status orchestrator.ItemStatus, cause error) {
+	logger := workflow.GetLogger(ctx)
+
+	now := workflow.Now(ctx)
+	// RegisterItem records the item even if it denies the registration, e.g. while draining.
+	_ = stateManager.RegisterItem(queued.ID, itemWorkflowID, "", queued.WorkflowName, now, queued.Payload)
+	report := orchestrator.NewCompletionReport(status.String(), cause, now, now)
+	if err := stateManager.Deregister(queued.ID, report, now); err != nil {
+		logger.Error("Failed to de-register item", "error", err, logging.ItemIDKey, queued.ID)
+	}
+}
+
+// handleChildCompletion releases the processing slot held by a child item workflow and deregisters it
+// with a completion report built from the child's result.
+func (ow *OW[O]) handleChildCompletion(ctx workflow.Context, stateManager O, completion childCompletion) {
+	logger := workflow.GetLogger(ctx)
+
+	if completion.Err != nil {
+		logger.Warn("Child item workflow failed", logging.ItemIDKey, completion.ID, "error", completion.Err)
+	} else {
+		logger.Info("Child item workflow completed", logging.ItemIDKey, completion.ID, "result", completion.Result)
+	}
+
+	if _, err := stateManager.StopProcessing(completion.ID); err != nil {
+		logger.Error("Failed to stop processing item", "error", err)
+	}
+	// A failed workflow has no result, describe the outcome by the error instead.
+	result := completion.Result
+	if completion.Err != nil && result == "" {
+		result = orchestrator.ItemStatusFailed.String()
+		if temporal.IsCanceledError(completion.Err) {
+			result = orchestrator.ItemStatusCancelled.String()
+		}
+	}
+	report := orchestrator.NewCompletionReport(result, completion.Err, completion.StartedAt, completion.FinishedAt)
+	if err := stateManager.Deregister(completion.ID, report, completion.FinishedAt); err != nil {
+		logger.Error("Failed to de-register item", "error", err)
+	}
+}
+
+// pruneItems removes the items that finished longer than the retention ago from the state, and then deletes the
+// claim check blobs of the pruned items that no history of this run refers to. Runs before protocol version 4
+// collected the blobs by age instead.
+func (ow *OW[O]) pruneItems(ctx workflow.Context, stateManager O, protocolVersion workflow.Version) {
+	logger := workflow.GetLogger(ctx)
+
+	var pruned []orchestrator.OrchestratedItem
+	if retention := stateManager.Config().Retention; retention > 0 {
+		pruned = stateManager.PruneItems(workflow.Now(ctx).Add(-retention))
+		if len(pruned) > 0 {
+			logger.Info("Pruned finished items", "count", len(pruned), "retention", retention)
+		}
+	}
+
+	if protocolVersion < 4 {
+		if len(pruned) > 0 {
+			ow.collectGarbage(ctx, stateManager)
+		}
+		return
+	}
+	state := stateManager.GetState()
+	for _, item := range pruned {
+		state.PrunedItems = append(state.PrunedItems, orchestrator.PrunedItem{
+			ID:                item.ID,
+			ItemWorkflowID:    item.ItemWorkflowID,
+			ItemWorkflowRunID: item.ItemWorkflowRunID,
+			FinishedAt:        item.FinishedAt(),
+		})
+	}
+	if len(state.PrunedItems) > 0 {
+		ow.deletePrunedBlobs(ctx, stateManager)
+	}
+}
+
+// deletePrunedBlobs deletes the claim check blobs of the pruned items that finished before this run started. The
+// signals and child workflow starts of the items that finished during this run are in its history, so their blobs
+// are deleted by the next run, see finish.
+func (ow *OW[O]) deletePrunedBlobs(ctx workflow.Context, stateManager O) {
+	logger := workflow.GetLogger(ctx)
+
+	state := stateManager.GetState()
+	runStartedAt := workflow.GetInfo(ctx).WorkflowStartTime
+	var executions []workflow.Execution
+	var remaining []orchestrator.PrunedItem
+	for _, item := range state.PrunedItems {
+		if !item.FinishedAt.Before(runStartedAt) {
+			remaining = append(remaining, item)
+			continue
+		}
+		// An item submitted again under the same ID may share the blobs, if they are not encrypted.
+		if lookup := orchestrator.LookupItem(stateManager.AllItems(), stateManager.QueuedItems(), item.ID); lookup.Item != nil || lookup.QueuedItem != nil {
+			continue
+		}
+		if item.ItemWorkflowID != "" {
+			executions = append(executions, workflow.Execution{ID: item.ItemWorkflowID, RunID: item.ItemWorkflowRunID})
+		}
+	}
+	if len(executions) == 0 {
+		state.PrunedItems = remaining
+		return
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 5 * time.Minute,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 3},
+	})
+	var deleted int
+	if err := workflow.ExecuteActivity(activityCtx, claimcheck.DeleteWorkflowBlobsActivityName, executions).Get(ctx, &deleted); err != nil {
+		// The blobs are deleted with the next pruned items.
+		logger.Error("Failed to delete the claim check blobs of pruned items", "error", err)
+		return
+	}
+	state.PrunedItems = remaining
+	logger.Info("Deleted the claim check blobs of pruned items", "items", len(executions), "deleted", deleted)
+}
+
+// finish ends the run once it has nothing left to do. If items pruned during the run are left, it continues as new
+// instead, so the next run deletes their claim check blobs at start, when no open history refers to them anymore.
+// Runs before protocol version 6 finished right away and left those blobs behind.
+func (ow *OW[O]) finish(ctx workflow.Context, stateManager O, protocolVersion workflow.Version) error {
+	if protocolVersion < 6 {
+		return nil
+	}
+	logger := workflow.GetLogger(ctx)
+
+	state := stateManager.GetState()
+	if len(state.PrunedItems) > 0 {
+		ow.deletePrunedBlobs(ctx, stateManager)
+	}
+	runStartedAt := workflow.GetInfo(ctx).WorkflowStartTime
+	for _, item := range state.PrunedItems {
+		if !item.FinishedAt.Before(runStartedAt) {
+			logger.Info("Continuing as new to delete the claim check blobs of pruned items", "prunedItems", len(state.PrunedItems))
+			return workflow.NewContinueAsNewError(ctx, ow.workflowAlias, *state)
+		}
+	}
+	if len(state.PrunedItems) > 0 {
+		logger.Warn("Finishing without deleting the claim check blobs of pruned items", "prunedItems", len(state.PrunedItems))
+	}
+	return nil
+}
+
+// collectGarbage runs the activity that collected the claim check blobs by age, for runs before protocol version 4.
+// The activity deletes nothing anymore.
+func (ow *OW[O]) collectGarbage(ctx workflow.Context, stateManager O) {
+	before := workflow.GetInfo(ctx).WorkflowStartTime
+	for _, item := range stateManager.AllItems() {
+		if item.RegisteredAt.Before(before) {
+			before = item.RegisteredAt
+		}
+	}
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	var deleted int
+	if err := workflow.ExecuteActivity(activityCtx, claimcheck.CollectGarbageActivityName, before.Add(-ClaimCheckGracePeriod)).Get(ctx, &deleted); err != nil {
+		workflow.GetLogger(ctx).Error("Failed to collect claim check blobs", "error", err)
+	}
+}
+
+// idleTimeout returns the configured idle timeout, or IdleTimeout if none is configured.
+func (ow *OW[O]) idleTimeout(stateManager O) time.Duration {
+	if timeout := stateManager.Config().IdleTimeout; timeout > 0 {
+		return timeout
+	}
+	return IdleTimeout
+}
+
+// setQueryHandlers registers the orchestrator queries. Queries do not issue commands, so they can change without
+// a version guard.
+func (ow *OW[O]) setQueryHandlers(ctx workflow.Context, stateManager O) error {
+	err := workflow.SetQueryHandler(ctx, orchestrator.QueryName, func() (orchestrator.QueryResponse, error) {
+		return ow.buildQueryResponse(stateManager), nil
+	})
+	if err != nil {
+		return err
+	}
+	err = workflow.SetQueryHandler(ctx, orchestrator.ItemsQueryName, func(request orchestrator.ItemsQueryRequest) (orchestrator.ItemsQueryResponse, error) {
+		return orchestrator.QueryItems(stateManager.AllItems(), stateManager.QueuedItems(), request)
+	})
+	if err != nil {
+		return err
+	}
+	err = workflow.SetQueryHandler(ctx, orchestrator.SummaryQueryName, func() (orchestrator.SummaryResponse, error) {
+		summary := orchestrator.Summarize(stateManager.AllItems(), stateManager.QueuedItems())
+		summary.SignalsHandled = stateManager.GetState().SignalsHandled
+		summary.Config = stateManager.Config()
+		summary.PrunedItems = stateManager.GetState().PrunedItems
+		return summary, nil
+	})
+	if err != nil {
+		return err
+	}
+	return workflow.SetQueryHandler(ctx, orchestrator.ItemLookupQueryName, func(itemID string) (orchestrator.ItemLookupResponse, error) {
+		return orchestrator.LookupItem(stateManager.AllItems(), stateManager.QueuedItems(), itemID), nil
+	})
+}
+
+// buildQueryResponse returns the entire state for the legacy query, which may exceed the payload size limit
+// with many items; clients use the paginated items query instead.
 func (ow *OW[O]) buildQueryResponse(stateManager O) orchestrator.QueryResponse {
 	return orchestrator.QueryResponse{
 		TotalItems:        len(stateManager.AllItems()),
 		OrchestratedItems: stateManager.AllItems(),
+		QueuedItems:       stateManager.QueuedItems(),
 		SignalsHandled:    stateManager.GetState().SignalsHandled,
+		Config:            stateManager.Config(),
+		PrunedItems:       stateManager.GetState().PrunedItems,
 	}
 }
 
 func main() {
-	c, err := client.Dial(client.Options{
-		HostPort: "passthrough:///localhost:7233",
-	})
+	cfg := config.New(orchestrator.TaskQueueName)
+	// Accept signals sent as protobuf by services in other languages.
+	cfg.BaseDataConverter = orchestratorpb.DataConverter
+	cfg.RegisterFlags(flag.CommandLine)
+	metricsListen := flag.String("metrics-listen", ":9090", "address of the Prometheus metrics endpoint, disabled if empty")
+	healthOptions := health.Options{Listen: ":8091", Drain: 10 * time.Second, StopTimeout: 30 * time.Second}
+	healthOptions.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	if err := cfg.Load(); err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	cfg.SetDefaultLogger()
+
+	shutdownTracing, err := cfg.StartTracing(context.Background(), "orchestrator-worker")
 	if err != nil {
-		log.Fatalln("Unable to create Temporal client", err)
+		logging.Fatal("Invalid configuration", "error", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	if *metricsListen != "" {
+		registry := metrics.NewRegistry()
+		cfg.MetricsHandler = registry.Handler()
+		serveMetrics(*metricsListen, registry)
+	}
+
+	c, err := cfg.Dial()
+	if err != nil {
+		logging.Fatal("Unable to create Temporal client", "error", err)
 	}
 	defer c.Close()
 
-	w := worker.New(c, "orchestrator-task-queue", worker.Options{})
+	// The item workflows upsert custom search attributes, which fail their workflow tasks if they are not registered.
+	orchestratorClient := orchestratorclient.New(c, cfg.TaskQueue)
+	err = orchestratorClient.RegisterSearchAttributes(context.Background(), cfg.Namespace)
+	if err != nil {
+		slog.Warn("Unable to register the search attributes, register them manually (see README)", "error", err)
+	}
+
+	claimCheckStore, err := cfg.ClaimCheckStore()
+	if err != nil {
+		logging.Fatal("Unable to open the claim check store", "error", err)
+	}
+
+	healthServer := health.New(c, cfg, healthOptions)
+	w := worker.New(c, cfg.TaskQueue, healthServer.WorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{logging.NewInterceptor(orchestrator.LogFields)},
+	}))
 
 	itemOrchestratorWorkflow := NewOW(orchestrator.OrchestratorWorkflowName, orchestrator.NewItemOrchestratorStateManager)
 	w.RegisterWorkflowWithOptions(itemOrchestratorWorkflow.OrchestratorWorkflow, workflow.RegisterOptions{Name: orchestrator.OrchestratorWorkflowName})
-	w.RegisterWorkflowWithOptions(ItemWorkflowA, workflow.RegisterOptions{Name: orchestrator.ItemWorkflowAName})
-	w.RegisterWorkflowWithOptions(ItemWorkflowB, workflow.RegisterOptions{Name: orchestrator.ItemWorkflowBName})
+	for _, itemType := range orchestrator.ItemTypes.Types() {
+		w.RegisterWorkflowWithOptions(itemType.Workflow, workflow.RegisterOptions{Name: itemType.WorkflowName})
+	}
+	w.RegisterWorkflowWithOptions(orchestrator.ScheduledItemWorkflow, workflow.RegisterOptions{Name: orchestrator.ScheduledItemWorkflowName})
+	w.RegisterActivityWithOptions(orchestratorClient.SubmitItemActivity, activity.RegisterOptions{Name: orchestrator.SubmitItemActivityName})
+
+	claimCheckActivities := &claimcheck.Activities{Store: claimCheckStore, Client: c}
+	w.RegisterActivityWithOptions(claimCheckActivities.DeleteWorkflowBlobs, activity.RegisterOptions{Name: claimcheck.DeleteWorkflowBlobsActivityName})
+	w.RegisterActivityWithOptions(claimCheckActivities.CollectGarbage, activity.RegisterOptions{Name: claimcheck.CollectGarbageActivityName})
 
-	log.Println("Starting Orchestrator and Item Workflow worker...")
-	if err := w.Run(worker.InterruptCh()); err != nil {
-		log.Fatalln("Unable to start worker", err)
+	slog.Info("Starting Orchestrator and Item Workflow worker", "config", cfg.String())
+	if err := healthServer.Run(w, health.InterruptCh()); err != nil {
+		logging.Fatal("Unable to start worker", "error", err)
 	}
 }
+
+// serveMetrics serves the metrics of registry at /metrics on addr, in the background.
+func serveMetrics(addr string, registry *metrics.Registry) {
+	handler, err := registry.HTTPHandler()
+	if err != nil {
+		logging.Fatal("Unable to create the metrics endpoint", "error", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", handler)
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		slog.Info("Serving Prometheus metrics", "address", addr, "path", "/metrics")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Fatal("Unable to serve metrics", "error", err)
+		}
+	}()
+}
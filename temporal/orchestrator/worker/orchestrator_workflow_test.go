@@ -0,0 +1,696 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"my-samples-go/temporal/claimcheck"
+	"my-samples-go/temporal/logging"
+	"my-samples-go/temporal/metrics"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorpb"
+	"my-samples-go/temporal/tracing"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newOrchestratorTestEnv(t *testing.T) (*testsuite.TestWorkflowEnvironment, *OW[orchestrator.OrchestratorStateManager]) {
+	return newOrchestratorTestEnvWithSuite(t, &testsuite.WorkflowTestSuite{})
+}
+
+func newOrchestratorTestEnvWithSuite(t *testing.T, testSuite *testsuite.WorkflowTestSuite) (*testsuite.TestWorkflowEnvironment, *OW[orchestrator.OrchestratorStateManager]) {
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.SetStartWorkflowOptions(client.StartWorkflowOptions{ID: orchestrator.OrchestratorWorkflowID})
+
+	ow := NewOW(orchestrator.OrchestratorWorkflowName, orchestrator.NewItemOrchestratorStateManager)
+	env.RegisterWorkflowWithOptions(ow.OrchestratorWorkflow, workflow.RegisterOptions{Name: orchestrator.OrchestratorWorkflowName})
+	for _, itemType := range orchestrator.ItemTypes.Types() {
+		env.RegisterWorkflowWithOptions(itemType.Workflow, workflow.RegisterOptions{Name: itemType.WorkflowName})
+	}
+	env.RegisterActivityWithOptions((&claimcheck.Activities{}).DeleteWorkflowBlobs, activity.RegisterOptions{Name: claimcheck.DeleteWorkflowBlobsActivityName})
+	return env, ow
+}
+
+func queryOrchestrator(t *testing.T, env *testsuite.TestWorkflowEnvironment) orchestrator.QueryResponse {
+	val, err := env.QueryWorkflow(orchestrator.QueryName)
+	require.NoError(t, err)
+	var resp orchestrator.QueryResponse
+	require.NoError(t, val.Get(&resp))
+	return resp
+}
+
+func Test_OrchestratorWorkflow_SubmittedItemsRunOneAtATime(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+
+	submit := func(id string, workflowName string, item orchestrator.Item) {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: id, WorkflowName: workflowName, Item: item},
+		})
+	}
+
+	env.RegisterDelayedCallback(func() {
+		submit("item-1", orchestrator.ItemWorkflowAName, orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: "item-1"}})
+		submit("item-2", orchestrator.ItemWorkflowBName, orchestrator.ItemB{BasicItem: orchestrator.BasicItem{Id: "item-2"}})
+		// Duplicate submissions are dropped.
+		submit("item-2", orchestrator.ItemWorkflowBName, orchestrator.ItemB{BasicItem: orchestrator.BasicItem{Id: "item-2"}})
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryOrchestrator(t, env)
+		require.True(t, resp.OrchestratedItems["item-1"].InProgress)
+		require.Len(t, resp.QueuedItems, 1)
+		require.Equal(t, "item-2", resp.QueuedItems[0].ID)
+	}, 10*time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryOrchestrator(t, env)
+		require.True(t, resp.OrchestratedItems["item-1"].Deregistered)
+		require.True(t, resp.OrchestratedItems["item-2"].InProgress)
+		require.Empty(t, resp.QueuedItems)
+	}, 45*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	resp := queryOrchestrator(t, env)
+	require.Equal(t, 2, resp.TotalItems)
+	for _, id := range []string{"item-1", "item-2"} {
+		item := resp.OrchestratedItems[id]
+		require.True(t, item.Deregistered, id)
+		require.False(t, item.InProgress, id)
+		require.NotEmpty(t, item.ItemWorkflowID, id)
+		require.False(t, item.RegisteredAt.IsZero(), id)
+		require.NotNil(t, item.Completion, id)
+		require.Equal(t, "Finished Successfully", item.Completion.Result, id)
+		require.Empty(t, item.Completion.Error, id)
+		require.Equal(t, 30*time.Second, item.Completion.Duration, id)
+	}
+	require.Equal(t, orchestrator.ItemWorkflowBName, resp.OrchestratedItems["item-2"].WorkflowName)
+}
+
+func Test_OrchestratorWorkflow_PauseResumeDrain(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+
+	signal := func(sig orchestrator.Signal) {
+		env.SignalWorkflow(orchestrator.SignalChannelName, sig)
+	}
+
+	env.RegisterDelayedCallback(func() {
+		signal(orchestrator.Signal{Type: orchestrator.PauseSignal})
+		signal(orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: "item-1", WorkflowName: orchestrator.ItemWorkflowAName, Item: orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: "item-1"}}},
+		})
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		// Paused, so the submitted item stays queued.
+		resp := queryOrchestrator(t, env)
+		require.True(t, resp.Config.Paused)
+		require.Len(t, resp.QueuedItems, 1)
+		require.Empty(t, resp.OrchestratedItems)
+
+		signal(orchestrator.Signal{Type: orchestrator.ResumeSignal})
+		signal(orchestrator.Signal{Type: orchestrator.DrainSignal})
+	}, 10*time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		// Draining: the running item finishes, new submissions are rejected.
+		resp := queryOrchestrator(t, env)
+		require.True(t, resp.Config.Draining)
+		require.False(t, resp.Config.Paused)
+		require.True(t, resp.OrchestratedItems["item-1"].InProgress)
+
+		signal(orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: "item-2", WorkflowName: orchestrator.ItemWorkflowBName, Item: orchestrator.ItemB{BasicItem: orchestrator.BasicItem{Id: "item-2"}}},
+		})
+	}, 20*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	resp := queryOrchestrator(t, env)
+	require.Len(t, resp.OrchestratedItems, 1)
+	require.True(t, resp.OrchestratedItems["item-1"].Deregistered)
+	require.Empty(t, resp.QueuedItems)
+}
+
+func Test_OrchestratorWorkflow_DrainDropsQueuedItems(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+
+	ids := []string{"item-1", "item-2", "item-3", "item-4"}
+	env.RegisterDelayedCallback(func() {
+		for _, id := range ids {
+			env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+				Type:    orchestrator.SubmitSignal,
+				Payload: orchestrator.SubmitPayload{ID: id, WorkflowName: orchestrator.ItemWorkflowAName, Item: orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: id}}},
+			})
+		}
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryOrchestrator(t, env)
+		require.Len(t, resp.QueuedItems, 3)
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{Type: orchestrator.DrainSignal})
+	}, 2*time.Second)
+
+	// At most one child runs at a time while draining.
+	for _, at := range []time.Duration{3 * time.Second, 20 * time.Second} {
+		env.RegisterDelayedCallback(func() {
+			resp := queryOrchestrator(t, env)
+			require.Empty(t, resp.QueuedItems)
+			inProgress := 0
+			for _, item := range resp.OrchestratedItems {
+				if item.InProgress {
+					inProgress++
+				}
+			}
+			require.Equal(t, 1, inProgress)
+			require.True(t, resp.OrchestratedItems["item-1"].InProgress)
+		}, at)
+	}
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	resp := queryOrchestrator(t, env)
+	require.Len(t, resp.OrchestratedItems, len(ids))
+	require.Equal(t, "Finished Successfully", resp.OrchestratedItems["item-1"].Completion.Result)
+	for _, id := range ids[1:] {
+		item := resp.OrchestratedItems[id]
+		require.True(t, item.Deregistered, id)
+		require.False(t, item.InProgress, id)
+		// The dropped items never ran as child workflows.
+		require.Empty(t, item.ItemWorkflowID, id)
+		require.NotNil(t, item.Completion, id)
+		require.Equal(t, orchestrator.ItemStatusCancelled.String(), item.Completion.Result, id)
+		require.Contains(t, item.Completion.Error, "draining", id)
+	}
+}
+
+func Test_OrchestratorWorkflow_PagedQueries(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+
+	env.RegisterDelayedCallback(func() {
+		for _, id := range []string{"item-1", "item-2", "other-3"} {
+			env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+				Type:    orchestrator.SubmitSignal,
+				Payload: orchestrator.SubmitPayload{ID: id, WorkflowName: orchestrator.ItemWorkflowBName, Item: orchestrator.ItemB{BasicItem: orchestrator.BasicItem{Id: id}}},
+			})
+		}
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		// item-1 is processing, the others are queued.
+		request := orchestrator.ItemsQueryRequest{PageSize: 1, IDPrefix: "item-"}
+		val, err := env.QueryWorkflow(orchestrator.ItemsQueryName, request)
+		require.NoError(t, err)
+		var page orchestrator.ItemsQueryResponse
+		require.NoError(t, val.Get(&page))
+		require.Len(t, page.Items, 1)
+		require.Equal(t, "item-1", page.Items[0].ID)
+		require.NotEmpty(t, page.NextPageToken)
+
+		request.PageToken = page.NextPageToken
+		val, err = env.QueryWorkflow(orchestrator.ItemsQueryName, request)
+		require.NoError(t, err)
+		page = orchestrator.ItemsQueryResponse{}
+		require.NoError(t, val.Get(&page))
+		require.Empty(t, page.Items)
+		require.Len(t, page.QueuedItems, 1)
+		require.Equal(t, "item-2", page.QueuedItems[0].ID)
+		require.Empty(t, page.NextPageToken)
+
+		val, err = env.QueryWorkflow(orchestrator.SummaryQueryName)
+		require.NoError(t, err)
+		var summary orchestrator.SummaryResponse
+		require.NoError(t, val.Get(&summary))
+		require.Equal(t, 1, summary.InProgressItems)
+		require.Equal(t, 2, summary.QueuedItems)
+		require.Equal(t, map[string]int{"b": 3}, summary.Types)
+		require.Equal(t, 4, summary.SignalsHandled) // the submissions and the status update of item-1
+
+		val, err = env.QueryWorkflow(orchestrator.ItemLookupQueryName, "other-3")
+		require.NoError(t, err)
+		var lookup orchestrator.ItemLookupResponse
+		require.NoError(t, val.Get(&lookup))
+		require.Nil(t, lookup.Item)
+		require.Equal(t, "other-3", lookup.QueuedItem.ID)
+	}, 10*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+}
+
+func Test_OrchestratorWorkflow_PrunesFinishedItems(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+	startedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	env.SetStartTime(startedAt)
+
+	var deletedBlobsOf [][]workflow.Execution
+	env.OnActivity(claimcheck.DeleteWorkflowBlobsActivityName, mock.Anything, mock.Anything).Return(func(_ context.Context, executions []workflow.Execution) (int, error) {
+		deletedBlobsOf = append(deletedBlobsOf, executions)
+		return len(executions), nil
+	})
+
+	submit := func(id string) {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: id, WorkflowName: orchestrator.ItemWorkflowAName, Item: orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: id}}},
+		})
+	}
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.ConfigSignal,
+			Payload: orchestrator.ConfigPayload{Retention: 30 * time.Second},
+		})
+		submit("item-1")
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		// item-1 finished more than 30s ago, so it is pruned when the next signal is handled.
+		submit("item-2")
+	}, 70*time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryOrchestrator(t, env)
+		require.NotContains(t, resp.OrchestratedItems, "item-0")
+		require.NotContains(t, resp.OrchestratedItems, "item-1")
+		require.True(t, resp.OrchestratedItems["item-2"].InProgress)
+		require.Equal(t, 30*time.Second, resp.Config.Retention)
+		// item-1 finished during this run, so the history of this run refers to its blobs.
+		require.Len(t, resp.PrunedItems, 1)
+		require.Equal(t, "item-1", resp.PrunedItems[0].ID)
+	}, 80*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{
+		OrchestratedItems: map[string]orchestrator.OrchestratedItem{
+			"item-0": {
+				ID: "item-0", ItemWorkflowID: "item-wf-0", ItemWorkflowRunID: "run-0", RegisteredAt: startedAt.Add(-2 * time.Hour), Deregistered: true,
+				Completion: orchestrator.NewCompletionReport("Finished Successfully", nil, startedAt.Add(-2*time.Hour), startedAt.Add(-time.Hour)),
+			},
+		},
+		// Pruned by the previous run after it was deregistered in it.
+		PrunedItems: []orchestrator.PrunedItem{{ID: "item-p", ItemWorkflowID: "item-wf-p", ItemWorkflowRunID: "run-p", FinishedAt: startedAt.Add(-time.Minute)}},
+	})
+
+	// The blobs of the items that finished before this run are deleted, those of item-p at start.
+	require.Equal(t, [][]workflow.Execution{{{ID: "item-wf-p", RunID: "run-p"}}, {{ID: "item-wf-0", RunID: "run-0"}}}, deletedBlobsOf)
+
+	// Once idle, the run continues as new instead of finishing, so the next run deletes the blobs of item-1.
+	require.True(t, env.IsWorkflowCompleted())
+	var continueAsNew *workflow.ContinueAsNewError
+	require.ErrorAs(t, env.GetWorkflowError(), &continueAsNew)
+	var next orchestrator.OrchestratorState
+	require.NoError(t, converter.GetDefaultDataConverter().FromPayloads(continueAsNew.Input, &next))
+	require.Len(t, next.PrunedItems, 1)
+	require.Equal(t, "item-1", next.PrunedItems[0].ID)
+	require.NotEmpty(t, next.PrunedItems[0].ItemWorkflowID)
+}
+
+func Test_OrchestratorWorkflow_DeletesPrunedBlobsBeforeFinishing(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+	startedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	env.SetStartTime(startedAt)
+
+	var deletedBlobsOf [][]workflow.Execution
+	env.OnActivity(claimcheck.DeleteWorkflowBlobsActivityName, mock.Anything, mock.Anything).Return(func(_ context.Context, executions []workflow.Execution) (int, error) {
+		deletedBlobsOf = append(deletedBlobsOf, executions)
+		return len(executions), nil
+	})
+
+	// The state a run continued as new with to delete the blobs of the items it pruned.
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{
+		PrunedItems: []orchestrator.PrunedItem{{ID: "item-1", ItemWorkflowID: "item-wf-1", ItemWorkflowRunID: "run-1", FinishedAt: startedAt.Add(-time.Minute)}},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.Equal(t, [][]workflow.Execution{{{ID: "item-wf-1", RunID: "run-1"}}}, deletedBlobsOf)
+}
+
+func Test_OrchestratorWorkflow_ProtoSignals(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+	env.SetDataConverter(orchestratorpb.DataConverter)
+
+	env.RegisterDelayedCallback(func() {
+		item, err := structpb.NewStruct(map[string]interface{}{"id": "item-1", "extraFieldB": "b"})
+		require.NoError(t, err)
+		env.SignalWorkflow(orchestrator.SignalChannelName, &orchestratorpb.Signal{
+			Type:    orchestratorpb.SignalType_SIGNAL_TYPE_SUBMIT,
+			Payload: &orchestratorpb.Signal_Submit{Submit: &orchestratorpb.SubmitPayload{Id: "item-1", WorkflowName: orchestrator.ItemWorkflowBName, Item: item}},
+		})
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryOrchestrator(t, env)
+		require.True(t, resp.OrchestratedItems["item-1"].InProgress)
+		require.Equal(t, orchestrator.ItemWorkflowBName, resp.OrchestratedItems["item-1"].WorkflowName)
+	}, 10*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.Equal(t, "Finished Successfully", queryOrchestrator(t, env).OrchestratedItems["item-1"].Completion.Result)
+}
+
+func Test_OrchestratorWorkflow_RejectsInvalidPayloads(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+
+	var instruction orchestrator.ItemInstructionSignal
+	env.OnSignalExternalWorkflow(mock.Anything, "item-wf-1", "run-1", orchestrator.ItemSignalChannelName, mock.Anything).Run(func(args mock.Arguments) {
+		instruction = args.Get(4).(orchestrator.ItemInstructionSignal)
+	}).Return(nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		// The item has a misspelled field.
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type: orchestrator.RegisterSignal,
+			Payload: map[string]interface{}{
+				"id": "item-1", "itemWorkflowId": "item-wf-1", "itemWorkflowRunId": "run-1", "workflowName": orchestrator.ItemWorkflowAName,
+				"item": map[string]interface{}{"id": "item-1", "extraFeildA": "a"},
+			},
+		})
+		// The item ID is missing.
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: map[string]interface{}{"workflowName": orchestrator.ItemWorkflowAName, "item": map[string]interface{}{"id": "item-2"}},
+		})
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		resp := queryOrchestrator(t, env)
+		require.Empty(t, resp.OrchestratedItems)
+		require.Empty(t, resp.QueuedItems)
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.False(t, instruction.Proceed)
+	require.Contains(t, instruction.Reason, `unknown property "extraFeildA"`)
+	env.AssertExpectations(t)
+}
+
+func Test_OrchestratorWorkflow_Metrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetMetricsHandler(registry.Handler())
+	env, ow := newOrchestratorTestEnvWithSuite(t, testSuite)
+
+	// An item workflow started directly registers, processes and deregisters, then an item is submitted.
+	env.OnSignalExternalWorkflow(mock.Anything, "item-wf-1", "run-1", orchestrator.ItemSignalChannelName, mock.Anything).Return(nil)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type: orchestrator.RegisterSignal,
+			Payload: orchestrator.RegisterPayload{
+				ID: "item-1", ItemWorkflowID: "item-wf-1", ItemWorkflowRunID: "run-1", WorkflowName: orchestrator.ItemWorkflowAName,
+				Item: orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: "item-1"}},
+			},
+		})
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.StartProcessingSignal,
+			Payload: orchestrator.StartProcessingPayload{ID: "item-1"},
+		})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.DeregisterSignal,
+			Payload: orchestrator.DeregisterPayload{ID: "item-1"},
+		})
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: "item-2", WorkflowName: orchestrator.ItemWorkflowBName, Item: orchestrator.ItemB{BasicItem: orchestrator.BasicItem{Id: "item-2"}}},
+		})
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	handler, err := registry.HTTPHandler()
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	var lines []string
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if strings.HasPrefix(line, "orchestrator_") || strings.HasPrefix(line, "item_") {
+			lines = append(lines, line)
+		}
+	}
+
+	require.Subset(t, lines, []string{
+		`orchestrator_signals_handled{signal_type="register"} 1`,
+		`orchestrator_signals_handled{signal_type="submit"} 1`,
+		`orchestrator_requests_granted{signal_type="register"} 1`,
+		`orchestrator_requests_granted{signal_type="start-processing"} 1`,
+		"orchestrator_queue_depth 0",
+		"orchestrator_items_registered 0",
+		"orchestrator_items_in_progress 0",
+		"item_processing_duration_seconds_count 1", // the submitted item
+	})
+	require.NotContains(t, strings.Join(lines, "\n"), "orchestrator_requests_denied")
+}
+
+func Test_OrchestratorWorkflow_CancelWithRetention(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+	env.OnSignalExternalWorkflow(mock.Anything, "item-wf-1", "run-1", orchestrator.ItemSignalChannelName, mock.Anything).Return(nil)
+
+	var registeredAt time.Time
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.ConfigSignal,
+			Payload: orchestrator.ConfigPayload{Retention: time.Hour},
+		})
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type: orchestrator.RegisterSignal,
+			Payload: orchestrator.RegisterPayload{
+				ID: "item-1", ItemWorkflowID: "item-wf-1", ItemWorkflowRunID: "run-1", WorkflowName: orchestrator.ItemWorkflowAName,
+				Item: orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: "item-1"}},
+			},
+		})
+		registeredAt = env.Now()
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		// Sent by orchestratorclient.CancelItem, which does not know when the item ran.
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.DeregisterSignal,
+			Payload: orchestrator.DeregisterPayload{ID: "item-1", Report: &orchestrator.CompletionReport{Result: "Cancelled", Error: "Cancelled by operator"}},
+		})
+	}, 10*time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		// The cancelled item finished just now, so it is kept for the retention.
+		require.Contains(t, queryOrchestrator(t, env).OrchestratedItems, "item-1")
+	}, 20*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	item := queryOrchestrator(t, env).OrchestratedItems["item-1"]
+	require.True(t, item.Deregistered)
+	require.NotNil(t, item.Completion)
+	require.Equal(t, "Cancelled", item.Completion.Result)
+	require.Equal(t, registeredAt.Unix(), item.Completion.StartedAt.Unix())
+	require.Equal(t, 9*time.Second, item.Completion.Duration)
+	require.Equal(t, item.Completion.StartedAt.Add(item.Completion.Duration), item.Completion.FinishedAt)
+}
+
+func Test_OrchestratorWorkflow_Tracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	env, ow := newOrchestratorTestEnv(t)
+	env.SetWorkerOptions(worker.Options{Interceptors: []interceptor.WorkerInterceptor{tracing.NewInterceptor(provider.Tracer("test"))}})
+
+	// The grant of a registration is sent in the trace of the register signal.
+	env.OnSignalExternalWorkflow(mock.Anything, "item-wf-1", "run-1", orchestrator.ItemSignalChannelName, mock.Anything).Return(nil)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type: orchestrator.RegisterSignal,
+			Payload: orchestrator.RegisterPayload{
+				ID: "item-1", ItemWorkflowID: "item-wf-1", ItemWorkflowRunID: "run-1", WorkflowName: orchestrator.ItemWorkflowAName,
+				Item: orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: "item-1"}},
+			},
+		})
+	}, time.Second)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.DeregisterSignal,
+			Payload: orchestrator.DeregisterPayload{ID: "item-1"},
+		})
+	}, 2*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	require.NoError(t, provider.ForceFlush(context.Background()))
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		if _, seen := spans[span.Name]; !seen {
+			spans[span.Name] = span
+		}
+	}
+	signal, ok := spans["HandleSignal:"+orchestrator.SignalChannelName]
+	require.True(t, ok)
+	grant, ok := spans["SignalExternalWorkflow:"+orchestrator.ItemSignalChannelName]
+	require.True(t, ok)
+	require.Equal(t, signal.SpanContext.TraceID(), grant.SpanContext.TraceID())
+	require.Equal(t, signal.SpanContext.SpanID(), grant.Parent.SpanID())
+}
+
+func Test_OrchestratorWorkflow_LogFields(t *testing.T) {
+	var buf bytes.Buffer
+	handler, err := logging.NewHandler(&buf, logging.FormatJSON, slog.LevelInfo)
+	require.NoError(t, err)
+	testSuite := &testsuite.WorkflowTestSuite{}
+	testSuite.SetLogger(log.NewStructuredLogger(slog.New(handler)))
+	env, ow := newOrchestratorTestEnvWithSuite(t, testSuite)
+	env.SetWorkerOptions(worker.Options{Interceptors: []interceptor.WorkerInterceptor{logging.NewInterceptor(orchestrator.LogFields)}})
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: "item-1", WorkflowName: orchestrator.ItemWorkflowBName, Item: orchestrator.ItemB{BasicItem: orchestrator.BasicItem{Id: "item-1"}}},
+		})
+	}, time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+	require.NoError(t, env.GetWorkflowError())
+
+	lines := map[string]map[string]interface{}{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var line map[string]interface{}
+		require.NoError(t, dec.Decode(&line))
+		if _, seen := lines[line["msg"].(string)]; !seen {
+			lines[line["msg"].(string)] = line
+		}
+	}
+	runID := lines["Orchestrator workflow started"][logging.OrchestratorRunIDKey]
+	require.NotEmpty(t, runID)
+	// The orchestrator names the item of the signal it handles, the item workflow its own item and orchestrator.
+	require.Subset(t, lines["Handling submit signal"], map[string]interface{}{logging.ItemIDKey: "item-1", logging.OrchestratorRunIDKey: runID})
+	require.Subset(t, lines["ItemWorkflowB started"], map[string]interface{}{
+		logging.ItemIDKey: "item-1", logging.ItemTypeKey: "b", logging.OrchestratorRunIDKey: runID,
+	})
+}
+
+// failingChildInterceptor fails the start of every child workflow of type workflowName, as if its workflow ID
+// were taken already.
+type failingChildInterceptor struct {
+	interceptor.WorkerInterceptorBase
+	workflowName string
+}
+
+func (i *failingChildInterceptor) InterceptWorkflow(ctx workflow.Context, next interceptor.WorkflowInboundInterceptor) interceptor.WorkflowInboundInterceptor {
+	return &failingChildWorkflowInbound{WorkflowInboundInterceptorBase: interceptor.WorkflowInboundInterceptorBase{Next: next}, workflowName: i.workflowName}
+}
+
+type failingChildWorkflowInbound struct {
+	interceptor.WorkflowInboundInterceptorBase
+	workflowName string
+}
+
+func (i *failingChildWorkflowInbound) Init(outbound interceptor.WorkflowOutboundInterceptor) error {
+	return i.Next.Init(&failingChildWorkflowOutbound{WorkflowOutboundInterceptorBase: interceptor.WorkflowOutboundInterceptorBase{Next: outbound}, workflowName: i.workflowName})
+}
+
+type failingChildWorkflowOutbound struct {
+	interceptor.WorkflowOutboundInterceptorBase
+	workflowName string
+}
+
+func (o *failingChildWorkflowOutbound) ExecuteChildWorkflow(ctx workflow.Context, childWorkflowType string, args ...interface{}) workflow.ChildWorkflowFuture {
+	if childWorkflowType != o.workflowName {
+		return o.Next.ExecuteChildWorkflow(ctx, childWorkflowType, args...)
+	}
+	future, settable := workflow.NewFuture(ctx)
+	settable.SetError(&temporal.ChildWorkflowExecutionAlreadyStartedError{})
+	return failedChildFuture{Future: future}
+}
+
+type failedChildFuture struct {
+	workflow.Future
+}
+
+func (f failedChildFuture) GetChildWorkflowExecution() workflow.Future {
+	return f.Future
+}
+
+func (f failedChildFuture) SignalChildWorkflow(ctx workflow.Context, signalName string, data interface{}) workflow.Future {
+	return f.Future
+}
+
+func Test_OrchestratorWorkflow_LaunchFails(t *testing.T) {
+	env, ow := newOrchestratorTestEnv(t)
+	env.SetWorkerOptions(worker.Options{Interceptors: []interceptor.WorkerInterceptor{&failingChildInterceptor{workflowName: orchestrator.ItemWorkflowBName}}})
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: "item-1", WorkflowName: orchestrator.ItemWorkflowBName, Item: orchestrator.ItemB{BasicItem: orchestrator.BasicItem{Id: "item-1"}}},
+		})
+		env.SignalWorkflow(orchestrator.SignalChannelName, orchestrator.Signal{
+			Type:    orchestrator.SubmitSignal,
+			Payload: orchestrator.SubmitPayload{ID: "item-2", WorkflowName: orchestrator.ItemWorkflowAName, Item: orchestrator.ItemA{BasicItem: orchestrator.BasicItem{Id: "item-2"}}},
+		})
+	}, time.Second)
+
+	env.RegisterDelayedCallback(func() {
+		// The item that failed to launch does not hold the slot, the next one is launched.
+		resp := queryOrchestrator(t, env)
+		require.Empty(t, resp.QueuedItems)
+		require.True(t, resp.OrchestratedItems["item-2"].InProgress)
+	}, 10*time.Second)
+
+	env.ExecuteWorkflow(ow.OrchestratorWorkflow, orchestrator.OrchestratorState{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	// The failed launch is recorded, so clients waiting for the item learn how it ended.
+	item := queryOrchestrator(t, env).OrchestratedItems["item-1"]
+	require.True(t, item.Deregistered)
+	require.False(t, item.InProgress)
+	require.Equal(t, orchestrator.ItemWorkflowBName, item.WorkflowName)
+	require.NotNil(t, item.Completion)
+	require.Equal(t, orchestrator.ItemStatusFailed.String(), item.Completion.Result)
+	require.Contains(t, item.Completion.Error, "already started")
+	require.False(t, item.Completion.FinishedAt.IsZero())
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"my-samples-go/temporal/orchestrator"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+type replayTestSuite struct {
+	suite.Suite
+}
+
+func TestReplayTestSuite(t *testing.T) {
+	s := new(replayTestSuite)
+	suite.Run(t, s)
+}
+
+// This replay test makes sure changes to the orchestrator and item workflows stay compatible with
+// executions recorded by earlier versions of the code. Every history under testdata is replayed.
+// "*_v0.json" were recorded before the workflow.GetVersion guards existed, the others with the version in their name.
+// A history can be downloaded with the Temporal CLI:
+//
+//	temporal workflow show -w orchestrator-workflow-singleton -o json > testdata/orchestrator_vN.json
+func (s *replayTestSuite) TestReplayWorkflowHistoriesFromFiles() {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	require.NoError(s.T(), err)
+	require.NotEmpty(s.T(), files)
+
+	replayer := worker.NewWorkflowReplayer()
+
+	ow := NewOW(orchestrator.OrchestratorWorkflowName, orchestrator.NewItemOrchestratorStateManager)
+	replayer.RegisterWorkflowWithOptions(ow.OrchestratorWorkflow, workflow.RegisterOptions{Name: orchestrator.OrchestratorWorkflowName})
+	for _, itemType := range orchestrator.ItemTypes.Types() {
+		replayer.RegisterWorkflowWithOptions(itemType.Workflow, workflow.RegisterOptions{Name: itemType.WorkflowName})
+	}
+
+	for _, file := range files {
+		s.Run(filepath.Base(file), func() {
+			err := replayer.ReplayWorkflowHistoryFromJSONFile(nil, file)
+			require.NoError(s.T(), err)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"my-samples-go/temporal/orchestrator"
+	"my-samples-go/temporal/orchestrator/orchestratorclient"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Test_SearchAttributes_DevServer runs the orchestrator on a development server, which is downloaded unless
+// TEMPORAL_CLI_PATH points to an installed Temporal CLI, and lists the item workflows through their search attributes.
+func Test_SearchAttributes_DevServer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a development server")
+	}
+	ctx := context.Background()
+	server, err := testsuite.StartDevServer(ctx, testsuite.DevServerOptions{
+		ExistingPath:  os.Getenv("TEMPORAL_CLI_PATH"),
+		ClientOptions: &client.Options{},
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, server.Stop()) }()
+
+	// Deferred calls run in reverse, the server only stops once the client is closed.
+	c := server.Client()
+	defer c.Close()
+	oc := orchestratorclient.New(c, "search-attributes-test")
+	require.NoError(t, oc.RegisterSearchAttributes(ctx, client.DefaultNamespace))
+	// Registering again is a no-op.
+	require.NoError(t, oc.RegisterSearchAttributes(ctx, client.DefaultNamespace))
+
+	w := worker.New(c, oc.TaskQueue, worker.Options{})
+	ow := NewOW(orchestrator.OrchestratorWorkflowName, orchestrator.NewItemOrchestratorStateManager)
+	w.RegisterWorkflowWithOptions(ow.OrchestratorWorkflow, workflow.RegisterOptions{Name: orchestrator.OrchestratorWorkflowName})
+	for _, itemType := range orchestrator.ItemTypes.Types() {
+		w.RegisterWorkflowWithOptions(itemType.Workflow, workflow.RegisterOptions{Name: itemType.WorkflowName})
+	}
+	require.NoError(t, w.Start())
+	defer w.Stop()
+
+	// A submitted item is launched right away and processes for 30 seconds.
+	item := orchestrator.ItemTypeB.NewItem("item-1", "", nil)
+	require.NoError(t, oc.SubmitItem(ctx, orchestrator.ItemTypeB, item))
+
+	search := func(filter orchestratorclient.SearchFilter) func() []orchestratorclient.ItemExecution {
+		return func() []orchestratorclient.ItemExecution {
+			require.NoError(t, filter.Validate())
+			executions, err := oc.SearchItems(ctx, filter, 0)
+			require.NoError(t, err)
+			return executions
+		}
+	}
+	processing := search(orchestratorclient.SearchFilter{Types: []string{"b"}, Statuses: []string{"Processing"}})
+	require.Eventually(t, func() bool { return len(processing()) == 1 }, 20*time.Second, 200*time.Millisecond)
+	execution := processing()[0]
+	require.Equal(t, "item-1", execution.ItemID)
+	require.Equal(t, orchestrator.DecisionLaunched.String(), execution.Decision)
+
+	// Cancelling fails the item, it can still be found once it was pruned from the orchestrator.
+	require.NoError(t, oc.CancelItem(ctx, "item-1"))
+	failed := search(orchestratorclient.SearchFilter{ItemIDs: []string{"item-1"}, Statuses: []string{"failed"}, Since: time.Hour})
+	require.Eventually(t, func() bool { return len(failed()) == 1 }, 20*time.Second, 200*time.Millisecond)
+	require.Equal(t, execution.WorkflowID, failed()[0].WorkflowID)
+	require.Empty(t, search(orchestratorclient.SearchFilter{Types: []string{"a"}})())
+}
@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// InstrumentationName is the name of the tracer the spans are created with.
+const InstrumentationName = "my-samples-go/temporal/tracing"
+
+// NewProvider returns a tracer provider that exports the spans of serviceName to the OTLP/HTTP endpoint,
+// e.g. http://localhost:4318 for a local collector or Jaeger. Shut it down before exiting, to export
+// the buffered spans.
+func NewProvider(ctx context.Context, endpoint string, serviceName string) (*sdktrace.TracerProvider, error) {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	), nil
+}
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/workflow"
+)
+
+// tracingInterceptor wraps the tracing interceptor of the SDK, and remembers the spans of the signals
+// a workflow receives, see SignalContext.
+type tracingInterceptor struct {
+	interceptor.Interceptor
+}
+
+func (t *tracingInterceptor) InterceptWorkflow(ctx workflow.Context, next interceptor.WorkflowInboundInterceptor) interceptor.WorkflowInboundInterceptor {
+	// The SDK interceptor runs first, so the signal spans are on the context the signals are handled with.
+	return t.Interceptor.InterceptWorkflow(ctx, &signalSpanInterceptor{
+		WorkflowInboundInterceptorBase: interceptor.WorkflowInboundInterceptorBase{Next: next},
+		spans:                          signalSpans{},
+	})
+}
+
+type signalSpansKey struct{}
+
+// signalSpans holds the spans of the received signals by signal name, oldest first.
+// A nil span stands for a signal received while replaying, which is not traced.
+type signalSpans map[string][]interface{}
+
+type signalSpanInterceptor struct {
+	interceptor.WorkflowInboundInterceptorBase
+	spans signalSpans
+}
+
+func (s *signalSpanInterceptor) ExecuteWorkflow(ctx workflow.Context, in *interceptor.ExecuteWorkflowInput) (interface{}, error) {
+	return s.Next.ExecuteWorkflow(workflow.WithValue(ctx, signalSpansKey{}, s.spans), in)
+}
+
+func (s *signalSpanInterceptor) HandleSignal(ctx workflow.Context, in *interceptor.HandleSignalInput) error {
+	s.spans[in.SignalName] = append(s.spans[in.SignalName], ctx.Value(spanContextKey{}))
+	return s.Next.HandleSignal(ctx, in)
+}
+
+// SignalContext returns ctx with the span of the oldest signal received on the channel signalName that was not
+// claimed yet, so the work the signal causes, e.g. the signals sent in reply, is traced in the trace of its sender.
+// Call it once for every signal received from the channel. Without tracing, ctx is returned unchanged.
+func SignalContext(ctx workflow.Context, signalName string) workflow.Context {
+	spans, traced := ctx.Value(signalSpansKey{}).(signalSpans)
+	if !traced || len(spans[signalName]) == 0 {
+		return ctx
+	}
+	span := spans[signalName][0]
+	spans[signalName] = spans[signalName][1:]
+	if span == nil {
+		return ctx
+	}
+	return workflow.WithValue(ctx, spanContextKey{}, span)
+}
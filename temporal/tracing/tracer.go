@@ -0,0 +1,120 @@
+// Package tracing traces the Temporal clients and workers of the samples with OpenTelemetry.
+//
+// The spans are created by the tracing interceptor of the Temporal SDK, which propagates the trace context
+// in the headers of workflows, activities and signals; Tracer adapts it to OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// HeaderKey is the Temporal header the trace context is propagated in.
+const HeaderKey = "_tracer-data"
+
+type spanContextKey struct{}
+
+// Tracer implements interceptor.Tracer with an OpenTelemetry tracer. The trace context is propagated
+// in the W3C Trace Context format.
+type Tracer struct {
+	interceptor.BaseTracer
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+var _ interceptor.Tracer = (*Tracer)(nil)
+
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{
+		tracer:     tracer,
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+}
+
+// NewInterceptor returns the interceptor that traces the clients and workers it is set on with tracer.
+// Set it on the client options, the workers created from the client use it too.
+func NewInterceptor(tracer trace.Tracer) interceptor.Interceptor {
+	return &tracingInterceptor{Interceptor: interceptor.NewTracingInterceptor(NewTracer(tracer))}
+}
+
+func (t *Tracer) Options() interceptor.TracerOptions {
+	return interceptor.TracerOptions{SpanContextKey: spanContextKey{}, HeaderKey: HeaderKey}
+}
+
+func (t *Tracer) UnmarshalSpan(m map[string]string) (interceptor.TracerSpanRef, error) {
+	spanContext := trace.SpanContextFromContext(t.propagator.Extract(context.Background(), propagation.MapCarrier(m)))
+	if !spanContext.IsValid() {
+		return nil, errors.New("failed extracting OpenTelemetry span from map")
+	}
+	return &tracerSpanRef{SpanContext: spanContext}, nil
+}
+
+func (t *Tracer) MarshalSpan(span interceptor.TracerSpan) (map[string]string, error) {
+	data := map[string]string{}
+	t.propagator.Inject(trace.ContextWithSpan(context.Background(), span.(*tracerSpan).Span), propagation.MapCarrier(data))
+	return data, nil
+}
+
+func (t *Tracer) SpanFromContext(ctx context.Context) interceptor.TracerSpan {
+	span := trace.SpanFromContext(ctx)
+	if !span.SpanContext().IsValid() {
+		return nil
+	}
+	return &tracerSpan{Span: span}
+}
+
+func (t *Tracer) ContextWithSpan(ctx context.Context, span interceptor.TracerSpan) context.Context {
+	return trace.ContextWithSpan(ctx, span.(*tracerSpan).Span)
+}
+
+func (t *Tracer) StartSpan(options *interceptor.TracerStartSpanOptions) (interceptor.TracerSpan, error) {
+	parent := context.Background()
+	switch p := options.Parent.(type) {
+	case nil:
+	case *tracerSpan:
+		parent = trace.ContextWithSpan(parent, p.Span)
+	case *tracerSpanRef:
+		parent = trace.ContextWithRemoteSpanContext(parent, p.SpanContext)
+	default:
+		return nil, errors.New("unrecognized parent span type")
+	}
+
+	attributes := make([]attribute.KeyValue, 0, len(options.Tags))
+	for k, v := range options.Tags {
+		attributes = append(attributes, attribute.String(k, v))
+	}
+	_, span := t.tracer.Start(parent, t.SpanName(options),
+		trace.WithTimestamp(options.Time),
+		trace.WithAttributes(attributes...),
+	)
+	return &tracerSpan{Span: span}, nil
+}
+
+type tracerSpanRef struct {
+	trace.SpanContext
+}
+
+type tracerSpan struct {
+	trace.Span
+}
+
+func (s *tracerSpan) Finish(options *interceptor.TracerFinishSpanOptions) {
+	if options.Error != nil && !isBenignError(options.Error) {
+		s.RecordError(options.Error)
+		s.SetStatus(codes.Error, options.Error.Error())
+	}
+	s.End()
+}
+
+// isBenignError reports errors that do not mark the span as failed, e.g. a workflow continuing as new.
+func isBenignError(err error) bool {
+	return workflow.IsContinueAsNewError(err) || temporal.IsCanceledError(err)
+}
@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+)
+
+func newTestTracer(t *testing.T) (*tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return exporter, provider
+}
+
+// spanByName returns the exported span with the given name, failing the test if there is none.
+func spanByName(t *testing.T, exporter *tracetest.InMemoryExporter, name string) tracetest.SpanStub {
+	var names []string
+	for _, span := range exporter.GetSpans() {
+		if span.Name == name {
+			return span
+		}
+		names = append(names, span.Name)
+	}
+	require.Failf(t, "span not found", "no span %q in %v", name, names)
+	return tracetest.SpanStub{}
+}
+
+func Test_Tracer_MarshalSpan(t *testing.T) {
+	_, provider := newTestTracer(t)
+	tracer := NewTracer(provider.Tracer("test"))
+
+	span, err := tracer.StartSpan(&interceptor.TracerStartSpanOptions{Operation: "StartWorkflow", Name: "wf", Time: time.Now()})
+	require.NoError(t, err)
+	data, err := tracer.MarshalSpan(span)
+	require.NoError(t, err)
+	require.Contains(t, data, "traceparent")
+
+	ref, err := tracer.UnmarshalSpan(data)
+	require.NoError(t, err)
+	require.Equal(t, span.(*tracerSpan).SpanContext().SpanID(), ref.(*tracerSpanRef).SpanID())
+	require.True(t, ref.(*tracerSpanRef).IsRemote())
+
+	_, err = tracer.UnmarshalSpan(map[string]string{})
+	require.Error(t, err)
+}
+
+// replyWorkflow replies to every signal with a signal to the workflow named in it.
+func replyWorkflow(ctx workflow.Context, useSignalContext bool) error {
+	ch := workflow.GetSignalChannel(ctx, "requests")
+	var target string
+	ch.Receive(ctx, &target)
+	replyCtx := ctx
+	if useSignalContext {
+		replyCtx = SignalContext(ctx, "requests")
+	}
+	return workflow.SignalExternalWorkflow(replyCtx, target, "", "replies", "ok").Get(ctx, nil)
+}
+
+func Test_SignalContext(t *testing.T) {
+	for _, useSignalContext := range []bool{true, false} {
+		exporter, provider := newTestTracer(t)
+		testSuite := &testsuite.WorkflowTestSuite{}
+		env := testSuite.NewTestWorkflowEnvironment()
+		env.SetWorkerOptions(worker.Options{Interceptors: []interceptor.WorkerInterceptor{NewInterceptor(provider.Tracer("test"))}})
+		env.RegisterWorkflow(replyWorkflow)
+		env.OnSignalExternalWorkflow(mock.Anything, "requester", "", "replies", "ok").Return(nil)
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow("requests", "requester")
+		}, time.Second)
+
+		env.ExecuteWorkflow(replyWorkflow, useSignalContext)
+		require.NoError(t, env.GetWorkflowError())
+
+		handled := spanByName(t, exporter, "HandleSignal:requests")
+		reply := spanByName(t, exporter, "SignalExternalWorkflow:replies")
+		run := spanByName(t, exporter, "RunWorkflow:replyWorkflow")
+		if useSignalContext {
+			require.Equal(t, handled.SpanContext.SpanID(), reply.Parent.SpanID(), "the reply belongs to the signal's trace")
+		} else {
+			require.Equal(t, run.SpanContext.SpanID(), reply.Parent.SpanID(), "the reply belongs to the workflow's trace")
+		}
+	}
+}
+
+func Test_SignalContext_WithoutTracing(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(replyWorkflow)
+	env.OnSignalExternalWorkflow(mock.Anything, "requester", "", "replies", "ok").Return(nil)
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow("requests", "requester")
+	}, time.Second)
+
+	env.ExecuteWorkflow(replyWorkflow, true)
+	require.NoError(t, env.GetWorkflowError())
+}